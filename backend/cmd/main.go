@@ -8,15 +8,18 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"sync"
 	"syscall"
 
 	"github.com/salmon822/test_task/internal/config"
 	"github.com/salmon822/test_task/internal/db"
 	"github.com/salmon822/test_task/internal/handler"
+	"github.com/salmon822/test_task/internal/musicinfo"
 	"github.com/salmon822/test_task/internal/pkg/logger"
 	"github.com/salmon822/test_task/internal/repository"
 	"github.com/salmon822/test_task/internal/server"
 	"github.com/salmon822/test_task/internal/service"
+	"github.com/salmon822/test_task/internal/worker"
 	"github.com/salmon822/test_task/migrations"
 )
 
@@ -58,7 +61,9 @@ func main() {
 
 	logging.Infof("Repository initialized successfully")
 
-	service, err := service.NewService(ctx, repo, logging)
+	musicInfo := musicinfo.NewClient(cfg.MusicInfo, logging)
+
+	service, err := service.NewService(ctx, cfg, repo, musicInfo, logging)
 	if err != nil {
 		logging.Panicf("Failed to initialize service: %v", err)
 	}
@@ -77,6 +82,11 @@ func main() {
 
 	router := handler.NewHandler(
 		service.Songs,
+		service.Artists,
+		service.Albums,
+		service.Tags,
+		service.SongLyrics,
+		service.SyncedLyrics,
 		cfg.Handler,
 		logging)
 
@@ -90,6 +100,23 @@ func main() {
 
 	logging.Infof("Server listening on port: %d", cfg.Server.Port)
 
+	workersCtx, stopWorkers := context.WithCancel(ctx)
+	var workers sync.WaitGroup
+
+	enrichmentWorker := worker.NewEnrichmentWorker(service.Enrichment, cfg.EnrichmentWorker.Interval, logging)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		enrichmentWorker.Run(workersCtx)
+	}()
+
+	trashPurgeWorker := worker.NewTrashPurgeWorker(service.Trash, cfg.TrashPurgeWorker.Interval, logging)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		trashPurgeWorker.Run(workersCtx)
+	}()
+
 	// graceful shutdown here
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
@@ -103,6 +130,9 @@ func main() {
 		logging.Errorf("Failed to gracefully shutdown server: %v", err)
 	}
 
+	stopWorkers()
+	workers.Wait()
+
 	logging.Infof("Server shutdown complete")
 	logging.Infof("Application finished")
 }
@@ -0,0 +1,212 @@
+package integration_tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/salmon822/test_task/integration_tests/song_helpers"
+	"github.com/salmon822/test_task/models"
+)
+
+type AlbumSuite struct {
+	TestSuite
+}
+
+func (s *AlbumSuite) SetupSuite() {
+	s.TestSuite.SetupSuite()
+}
+
+func (s *AlbumSuite) TestCreateAlbumSuccess() {
+	req := models.AlbumCreateRequest{
+		Album: &models.Album{
+			GroupName:   "Muse",
+			Title:       "Black Holes and Revelations",
+			ReleaseDate: makeDate("2006-07-03"),
+			CoverLink:   "http://cover.com/bhar.jpg",
+		},
+	}
+	var albumCreateRes models.Album
+
+	_, err := makeJsonRequest(s.httpHandler, http.MethodPost, "/albums/create", req, &albumCreateRes)
+	s.Require().NoError(err)
+
+	s.Require().Equal(int64(1), albumCreateRes.Id)
+	s.Require().Equal(makePointer(int64(1)), albumCreateRes.ArtistId)
+	s.Require().Equal("Black Holes and Revelations", albumCreateRes.Title)
+}
+
+func (s *AlbumSuite) TestCreateSongOnAlbum() {
+	ctx := context.Background()
+
+	albumId, err := song_helpers.CreateAlbum(ctx, s.pgClient, "Muse", "Absolution")
+	s.Require().NoError(err)
+
+	req := models.SongCreateRequest{
+		Song: &models.Song{
+			GroupName:   "Muse",
+			SongTitle:   "Hysteria",
+			ReleaseDate: makeDate("2003-12-01"),
+			SongText:    "It's bugging me",
+			Link:        "http://hysteria.com",
+			AlbumId:     makePointer(albumId),
+			TrackNumber: makePointer(int64(8)),
+		},
+	}
+	var songCreateRes models.Song
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPost, "/songs/create", req, &songCreateRes)
+	s.Require().NoError(err)
+
+	s.Require().Equal(makePointer(albumId), songCreateRes.AlbumId)
+	s.Require().Equal(makePointer(int64(1)), songCreateRes.DiscNumber)
+	s.Require().Equal(makePointer(int64(8)), songCreateRes.TrackNumber)
+}
+
+func (s *AlbumSuite) TestGetAlbumTracksOrdered() {
+	ctx := context.Background()
+
+	albumId, err := song_helpers.CreateAlbum(ctx, s.pgClient, "Muse", "Absolution")
+	s.Require().NoError(err)
+
+	_, err = song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithGroupName("Muse"),
+		song_helpers.WithSongTitle("Second"), song_helpers.WithAlbumTrack(albumId, 1, 2))
+	s.Require().NoError(err)
+	_, err = song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithGroupName("Muse"),
+		song_helpers.WithSongTitle("Bonus"), song_helpers.WithAlbumTrack(albumId, 2, 1))
+	s.Require().NoError(err)
+	_, err = song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithGroupName("Muse"),
+		song_helpers.WithSongTitle("First"), song_helpers.WithAlbumTrack(albumId, 1, 1))
+	s.Require().NoError(err)
+
+	var tracksRes models.AlbumTracksResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, fmt.Sprintf("/albums/%d/tracks", albumId), nil, &tracksRes)
+	s.Require().NoError(err)
+
+	s.Require().Equal("Absolution", tracksRes.Album.Title)
+	s.Require().Len(tracksRes.Tracks, 3)
+	s.Require().Equal("First", tracksRes.Tracks[0].SongTitle)
+	s.Require().Equal("Second", tracksRes.Tracks[1].SongTitle)
+	s.Require().Equal("Bonus", tracksRes.Tracks[2].SongTitle)
+}
+
+func (s *AlbumSuite) TestReorderAlbumTracksSwap() {
+	ctx := context.Background()
+
+	albumId, err := song_helpers.CreateAlbum(ctx, s.pgClient, "Muse", "Absolution")
+	s.Require().NoError(err)
+
+	first, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithGroupName("Muse"),
+		song_helpers.WithSongTitle("First"), song_helpers.WithAlbumTrack(albumId, 1, 1))
+	s.Require().NoError(err)
+	second, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithGroupName("Muse"),
+		song_helpers.WithSongTitle("Second"), song_helpers.WithAlbumTrack(albumId, 1, 2))
+	s.Require().NoError(err)
+
+	req := models.AlbumTracksReorderRequest{
+		Tracks: []models.TrackPosition{
+			{SongId: first, TrackNumber: 2},
+			{SongId: second, TrackNumber: 1},
+		},
+	}
+	var tracksRes models.AlbumTracksResponse
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPut, fmt.Sprintf("/albums/%d/tracks/order", albumId), req, &tracksRes)
+	s.Require().NoError(err)
+
+	s.Require().Len(tracksRes.Tracks, 2)
+	s.Require().Equal("Second", tracksRes.Tracks[0].SongTitle)
+	s.Require().Equal("First", tracksRes.Tracks[1].SongTitle)
+
+	var revisions []models.SongRevision
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, fmt.Sprintf("/songs/%d/revisions", first), nil, &revisions)
+	s.Require().NoError(err)
+	s.Require().Len(revisions, 1)
+	s.Require().Equal(models.SongRevisionActionUpdate, revisions[0].Action)
+}
+
+func (s *AlbumSuite) TestReorderAlbumTracksAlbumNotFound() {
+	ctx := context.Background()
+
+	song, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongTitle("Single"))
+	s.Require().NoError(err)
+
+	req := models.AlbumTracksReorderRequest{
+		Tracks: []models.TrackPosition{{SongId: song, TrackNumber: 1}},
+	}
+
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodPut, "/albums/999999/tracks/order", req)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusNotFound), errResp.Status)
+}
+
+func (s *AlbumSuite) TestReorderAlbumTracksTrashedSong() {
+	ctx := context.Background()
+
+	albumId, err := song_helpers.CreateAlbum(ctx, s.pgClient, "Muse", "Showbiz")
+	s.Require().NoError(err)
+	trashed, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithAlbumTrack(albumId, 1, 1))
+	s.Require().NoError(err)
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodDelete, fmt.Sprintf("/songs/%d/delete", trashed), nil, nil)
+	s.Require().NoError(err)
+
+	req := models.AlbumTracksReorderRequest{
+		Tracks: []models.TrackPosition{{SongId: trashed, TrackNumber: 2}},
+	}
+
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodPut, fmt.Sprintf("/albums/%d/tracks/order", albumId), req)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusUnprocessableEntity), errResp.Status)
+}
+
+func (s *AlbumSuite) TestReorderAlbumTracksForeignSong() {
+	ctx := context.Background()
+
+	albumId, err := song_helpers.CreateAlbum(ctx, s.pgClient, "Muse", "Absolution")
+	s.Require().NoError(err)
+
+	track, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithGroupName("Muse"),
+		song_helpers.WithSongTitle("Track"), song_helpers.WithAlbumTrack(albumId, 1, 1))
+	s.Require().NoError(err)
+	single, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithGroupName("Muse"),
+		song_helpers.WithSongTitle("Single"))
+	s.Require().NoError(err)
+
+	req := models.AlbumTracksReorderRequest{
+		Tracks: []models.TrackPosition{
+			{SongId: track, TrackNumber: 2},
+			{SongId: single, TrackNumber: 1},
+		},
+	}
+
+	_, err = makeJsonRequestWithErrorResp(s.httpHandler, http.MethodPut, fmt.Sprintf("/albums/%d/tracks/order", albumId), req)
+	s.Require().NoError(err)
+
+	// The whole reorder is rolled back.
+	var tracksRes models.AlbumTracksResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, fmt.Sprintf("/albums/%d/tracks", albumId), nil, &tracksRes)
+	s.Require().NoError(err)
+
+	s.Require().Len(tracksRes.Tracks, 1)
+	s.Require().Equal(makePointer(int64(1)), tracksRes.Tracks[0].TrackNumber)
+}
+
+func (s *AlbumSuite) TestDeletedSongFreesAlbumTrack() {
+	ctx := context.Background()
+
+	albumId, err := song_helpers.CreateAlbum(ctx, s.pgClient, "Muse", "Origin of Symmetry")
+	s.Require().NoError(err)
+	deletedSong, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithAlbumTrack(albumId, 1, 1))
+	s.Require().NoError(err)
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodDelete, fmt.Sprintf("/songs/%d/delete", deletedSong), nil, nil)
+	s.Require().NoError(err)
+
+	_, err = song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithAlbumTrack(albumId, 1, 1))
+	s.Require().NoError(err)
+
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodPost, fmt.Sprintf("/songs/%d/restore", deletedSong), nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusConflict), errResp.Status)
+}
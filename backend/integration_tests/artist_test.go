@@ -0,0 +1,152 @@
+package integration_tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/salmon822/test_task/integration_tests/song_helpers"
+	"github.com/salmon822/test_task/models"
+)
+
+type ArtistSuite struct {
+	TestSuite
+}
+
+func (s *ArtistSuite) SetupSuite() {
+	s.TestSuite.SetupSuite()
+}
+
+func (s *ArtistSuite) TestCreateArtistSuccess() {
+	req := models.ArtistCreateRequest{
+		Artist: &models.Artist{
+			Name: "  Test   Artist ",
+		},
+	}
+	var artistCreateRes models.Artist
+
+	_, err := makeJsonRequest(s.httpHandler, http.MethodPost, "/artists/create", req, &artistCreateRes)
+	s.Require().NoError(err)
+
+	s.Require().Equal(int64(1), artistCreateRes.Id)
+	s.Require().Equal("Test Artist", artistCreateRes.Name)
+}
+
+func (s *ArtistSuite) TestCreateArtistInnerWhitespaceConflict() {
+	ctx := context.Background()
+
+	_, err := song_helpers.CreateArtist(ctx, s.pgClient, "Test  Artist")
+	s.Require().NoError(err)
+
+	req := models.ArtistCreateRequest{
+		Artist: &models.Artist{
+			Name: "test artist",
+		},
+	}
+
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodPost, "/artists/create", req)
+	s.Require().NoError(err)
+
+	s.Require().Equal(int64(http.StatusConflict), errResp.Status)
+	s.Require().Equal(models.Conflict, errResp.Type)
+}
+
+func (s *ArtistSuite) TestCreateArtistValidationFailed() {
+	req := models.ArtistCreateRequest{
+		Artist: &models.Artist{
+			Name: "   ",
+		},
+	}
+
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodPost, "/artists/create", req)
+	s.Require().NoError(err)
+
+	s.Require().Equal(int64(http.StatusUnprocessableEntity), errResp.Status)
+	s.Require().Equal(models.ValidationFailed, errResp.Type)
+	s.Require().NotNil(errResp.Errors)
+	s.Require().Equal([]models.ErrorResponseField{{Pointer: "/artist/name", Detail: "cannot be blank"}}, *errResp.Errors)
+}
+
+func (s *ArtistSuite) TestRenameArtistRenamesSongs() {
+	ctx := context.Background()
+
+	songId, err := song_helpers.CreateSong(ctx, s.pgClient,
+		song_helpers.WithGroupName("Old Name"),
+		song_helpers.WithSongTitle("TestSong"))
+	s.Require().NoError(err)
+	songUrl := fmt.Sprintf("/api/v1/songs/%d", songId)
+
+	header, err := makeJsonRequestWithHeaders(s.httpHandler, http.MethodGet, songUrl, nil, nil, nil)
+	s.Require().NoError(err)
+	oldETag := header.Get("ETag")
+
+	artistId, err := song_helpers.CreateArtist(ctx, s.pgClient, "Old Name")
+	s.Require().NoError(err)
+
+	req := models.ArtistUpdateRequest{
+		Artist: &models.Artist{
+			Name: "New Name",
+		},
+	}
+	var artistUpdateRes models.Artist
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPatch, fmt.Sprintf("/artists/%d/update", artistId), req, &artistUpdateRes)
+	s.Require().NoError(err)
+	s.Require().Equal("New Name", artistUpdateRes.Name)
+
+	var filteredSongs models.SongListResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, fmt.Sprintf("/songs/filter?artistId=%d", artistId), nil, &filteredSongs)
+	s.Require().NoError(err)
+
+	s.Require().Len(filteredSongs.Items, 1)
+	s.Require().Equal("New Name", filteredSongs.Items[0].GroupName)
+
+	header, err = makeJsonRequestWithHeaders(s.httpHandler, http.MethodGet, songUrl, nil, nil, nil)
+	s.Require().NoError(err)
+	s.Require().NotEqual(oldETag, header.Get("ETag"))
+}
+
+func (s *ArtistSuite) TestGetArtistsSuccess() {
+	ctx := context.Background()
+
+	_, err := song_helpers.CreateArtist(ctx, s.pgClient, "Muse")
+	s.Require().NoError(err)
+	_, err = song_helpers.CreateArtist(ctx, s.pgClient, "Metallica")
+	s.Require().NoError(err)
+
+	var artists []models.Artist
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/artists/filter?name=mus", nil, &artists)
+	s.Require().NoError(err)
+
+	s.Require().Len(artists, 1)
+	s.Require().Equal("Muse", artists[0].Name)
+}
+
+func (s *ArtistSuite) TestDeleteArtistSuccess() {
+	ctx := context.Background()
+
+	artistId, err := song_helpers.CreateArtist(ctx, s.pgClient, "To Delete")
+	s.Require().NoError(err)
+
+	var successRes models.SuccessResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodDelete, fmt.Sprintf("/artists/%d/delete", artistId), nil, &successRes)
+	s.Require().NoError(err)
+
+	s.Require().Equal(makePointer(true), successRes.Success)
+}
+
+func (s *ArtistSuite) TestDeleteArtistWithSongsConflict() {
+	ctx := context.Background()
+
+	_, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithGroupName("Muse"))
+	s.Require().NoError(err)
+
+	artistId, err := song_helpers.CreateArtist(ctx, s.pgClient, "Muse")
+	s.Require().NoError(err)
+
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodDelete, fmt.Sprintf("/artists/%d/delete", artistId), nil)
+	s.Require().NoError(err)
+
+	s.Require().Equal(int64(http.StatusConflict), errResp.Status)
+	s.Require().Equal(models.Conflict, errResp.Type)
+}
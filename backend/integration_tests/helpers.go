@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"time"
 
 	"github.com/salmon822/test_task/models"
 )
@@ -15,16 +17,37 @@ func makePointer[T any](value T) *T {
 	return &value
 }
 
+func makeDate(value string) *models.Date {
+	date, err := time.Parse(models.DateFormat, value)
+	if err != nil {
+		panic(err)
+	}
+	return &models.Date{Time: date}
+}
+
 func makeJsonRequest(handler http.Handler, method string, url string, body any, res any) (string, error) {
+	header, err := makeJsonRequestWithHeaders(handler, method, url, body, nil, res)
+	if err != nil {
+		return "", err
+	}
+	return header.Get("Set-Cookie"), nil
+}
+
+// makeJsonRequestWithHeaders sends the request with the given headers and
+// returns the headers of the response.
+func makeJsonRequestWithHeaders(handler http.Handler, method string, url string, body any, headers map[string]string, res any) (http.Header, error) {
 	var b io.Reader
 	if body != nil {
 		data, err := json.Marshal(body)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		b = bytes.NewReader(data)
 	}
 	httpReq := httptest.NewRequest(method, url, b)
+	for key, value := range headers {
+		httpReq.Header.Set(key, value)
+	}
 
 	recorder := httptest.NewRecorder()
 	handler.ServeHTTP(recorder, httpReq)
@@ -33,22 +56,28 @@ func makeJsonRequest(handler http.Handler, method string, url string, body any,
 
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("non 200 response: %d %s", resp.StatusCode, string(data))
+		return nil, fmt.Errorf("non 200 response: %d %s", resp.StatusCode, string(data))
 	}
 
 	if res != nil {
 		if err = json.Unmarshal(data, res); err != nil {
-			return "", fmt.Errorf("json unmarshal failed: %w %s", err, string(data))
+			return nil, fmt.Errorf("json unmarshal failed: %w %s", err, string(data))
 		}
 	}
-	return resp.Header.Get("Set-Cookie"), nil
+	return resp.Header, nil
 }
 
 func makeJsonRequestWithErrorResp(handler http.Handler, method string, url string, body any) (models.ErrorResponse, error) {
+	return makeJsonRequestWithHeadersErrorResp(handler, method, url, body, nil)
+}
+
+// makeJsonRequestWithHeadersErrorResp sends the request with the given headers
+// and returns the error of the response.
+func makeJsonRequestWithHeadersErrorResp(handler http.Handler, method string, url string, body any, headers map[string]string) (models.ErrorResponse, error) {
 	var b io.Reader
 	if body != nil {
 		data, err := json.Marshal(body)
@@ -58,6 +87,9 @@ func makeJsonRequestWithErrorResp(handler http.Handler, method string, url strin
 		b = bytes.NewReader(data)
 	}
 	httpReq := httptest.NewRequest(method, url, b)
+	for key, value := range headers {
+		httpReq.Header.Set(key, value)
+	}
 
 	recorder := httptest.NewRecorder()
 	handler.ServeHTTP(recorder, httpReq)
@@ -80,3 +112,21 @@ func makeJsonRequestWithErrorResp(handler http.Handler, method string, url strin
 
 	return e, nil
 }
+
+// makeTextRequest sends the body as is and returns the status code and the
+// body of the response.
+func makeTextRequest(handler http.Handler, method string, url string, body string) (int, string, error) {
+	httpReq := httptest.NewRequest(method, url, strings.NewReader(body))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httpReq)
+	resp := recorder.Result()
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return resp.StatusCode, string(data), nil
+}
@@ -0,0 +1,53 @@
+package integration_tests
+
+import (
+	"net/http"
+
+	"github.com/salmon822/test_task/internal/handler/writes"
+	"github.com/salmon822/test_task/internal/musicinfo"
+)
+
+const (
+	fakeMusicInfoGroup       = "Muse"
+	fakeMusicInfoSong        = "Supermassive Black Hole"
+	fakeMusicInfoBrokenGroup = "Broken Upstream"
+	fakeMusicInfoBadDateSong = "Knights of Cydonia"
+)
+
+var fakeMusicInfoDetail = musicinfo.SongDetail{
+	ReleaseDate: "16.07.2006",
+	Text:        "Ooh baby, don't you know I suffer?\nOoh baby, can you hear me moan?",
+	Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+}
+
+// fakeMusicInfoBadDateDetail is returned for fakeMusicInfoBadDateSong, its
+// release date cannot be parsed.
+var fakeMusicInfoBadDateDetail = musicinfo.SongDetail{
+	ReleaseDate: "summer 2006",
+	Text:        "Come ride with me through the veins of history",
+	Link:        "https://www.youtube.com/watch?v=G_sBOsh-vyI",
+}
+
+// newFakeMusicInfoHandler emulates the external music info service: it knows a
+// couple of songs, answers 404 for everything else and 500 for the broken
+// group.
+func newFakeMusicInfoHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/info", func(w http.ResponseWriter, r *http.Request) {
+		group := r.URL.Query().Get("group")
+		song := r.URL.Query().Get("song")
+
+		switch {
+		case group == fakeMusicInfoBrokenGroup:
+			w.WriteHeader(http.StatusInternalServerError)
+		case group == fakeMusicInfoGroup && song == fakeMusicInfoSong:
+			writes.WriteResponseWithErrorLog(w, http.StatusOK, fakeMusicInfoDetail)
+		case group == fakeMusicInfoGroup && song == fakeMusicInfoBadDateSong:
+			writes.WriteResponseWithErrorLog(w, http.StatusOK, fakeMusicInfoBadDateDetail)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	return mux
+}
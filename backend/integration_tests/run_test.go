@@ -8,4 +8,10 @@ import (
 
 func TestSuiteRun(t *testing.T) {
 	suite.Run(t, new(SongSuite))
+	suite.Run(t, new(ArtistSuite))
+	suite.Run(t, new(AlbumSuite))
+	suite.Run(t, new(TagSuite))
+	suite.Run(t, new(SongLyricsSuite))
+	suite.Run(t, new(SyncedLyricsSuite))
+	suite.Run(t, new(SongRevisionsSuite))
 }
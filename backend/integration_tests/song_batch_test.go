@@ -0,0 +1,105 @@
+package integration_tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/salmon822/test_task/integration_tests/song_helpers"
+	"github.com/salmon822/test_task/models"
+)
+
+func (s *SongSuite) applySongBatch(req models.SongBatchRequest) (int, models.SongBatchResponse) {
+	body, err := json.Marshal(req)
+	s.Require().NoError(err)
+
+	code, data, err := makeTextRequest(s.httpHandler, http.MethodPost, "/songs/batch", string(body))
+	s.Require().NoError(err)
+
+	var res models.SongBatchResponse
+	s.Require().NoError(json.Unmarshal([]byte(data), &res), data)
+
+	return code, res
+}
+
+func (s *SongSuite) TestSongBatchAtomic() {
+	ctx := context.Background()
+
+	songID, err := song_helpers.CreateSong(ctx, s.pgClient)
+	s.Require().NoError(err)
+
+	code, res := s.applySongBatch(models.SongBatchRequest{Operations: []models.SongBatchOperation{
+		{Op: models.SongBatchOperationOpCreate, Song: &models.Song{GroupName: "Muse", SongTitle: "Uprising"}},
+		{Op: models.SongBatchOperationOpUpdate, Id: &songID, Song: &models.Song{SongTitle: "Starlight"}},
+		{Op: models.SongBatchOperationOpUpdate, Id: makePointer(int64(42)), Song: &models.Song{SongTitle: "Knights of Cydonia"}},
+	}})
+	s.Require().Equal(http.StatusUnprocessableEntity, code)
+
+	s.Require().False(res.Committed)
+	s.Require().Equal(models.SongBatchModeAtomic, res.Mode)
+	s.Require().Equal(int64(0), res.Succeeded)
+	s.Require().Equal(int64(1), res.Failed)
+	s.Require().Equal(models.SongBatchResultStatusSkipped, res.Results[0].Status)
+	s.Require().Nil(res.Results[0].Id)
+	s.Require().Equal(models.SongBatchResultStatusFailed, res.Results[2].Status)
+	s.Require().Equal(models.NotFound, res.Results[2].Error.Type)
+
+	var list models.SongListResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter", nil, &list)
+	s.Require().NoError(err)
+	s.Require().Len(list.Items, 1)
+	s.Require().NotEqual("Starlight", list.Items[0].SongTitle)
+
+	code, res = s.applySongBatch(models.SongBatchRequest{Operations: []models.SongBatchOperation{
+		{Op: models.SongBatchOperationOpCreate, Song: &models.Song{GroupName: "Muse", SongTitle: "Uprising"}},
+		{Op: models.SongBatchOperationOpUpdate, Id: &songID, Song: &models.Song{SongTitle: "Starlight"}},
+		{Op: models.SongBatchOperationOpDelete, Id: &songID, Version: makePointer(int64(2))},
+	}})
+	s.Require().Equal(http.StatusOK, code)
+
+	s.Require().True(res.Committed)
+	s.Require().Equal(int64(3), res.Succeeded)
+	s.Require().NotNil(res.Results[0].Id)
+	s.Require().Equal("Uprising", res.Results[0].Song.SongTitle)
+	s.Require().Equal(makePointer(models.Pending), res.Results[0].Song.EnrichmentStatus)
+	s.Require().Equal(songID, *res.Results[2].Id)
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter", nil, &list)
+	s.Require().NoError(err)
+	s.Require().Len(list.Items, 1)
+	s.Require().Equal("Uprising", list.Items[0].SongTitle)
+}
+
+func (s *SongSuite) TestSongBatchIndependent() {
+	ctx := context.Background()
+
+	songID, err := song_helpers.CreateSong(ctx, s.pgClient)
+	s.Require().NoError(err)
+
+	mode := models.SongBatchModeIndependent
+	code, res := s.applySongBatch(models.SongBatchRequest{Mode: &mode, Operations: []models.SongBatchOperation{
+		{Op: models.SongBatchOperationOpCreate, Song: &models.Song{GroupName: "", SongTitle: "Uprising"}},
+		{Op: models.SongBatchOperationOpUpdate, Id: &songID, Song: &models.Song{SongTitle: "Starlight"}},
+		{Op: models.SongBatchOperationOpDelete, Id: &songID, Version: makePointer(int64(1))},
+	}})
+	s.Require().Equal(http.StatusOK, code)
+
+	s.Require().True(res.Committed)
+	s.Require().Equal(int64(1), res.Succeeded)
+	s.Require().Equal(int64(2), res.Failed)
+
+	s.Require().Equal(models.ValidationFailed, res.Results[0].Error.Type)
+	s.Require().NotNil(res.Results[0].Error.Errors)
+	s.Require().Equal("/operations/0/song/groupName", (*res.Results[0].Error.Errors)[0].Pointer)
+	s.Require().Equal(models.SongBatchResultStatusSucceeded, res.Results[1].Status)
+	s.Require().Equal("Starlight", res.Results[1].Song.SongTitle)
+	s.Require().Equal(models.PreconditionFailed, res.Results[2].Error.Type)
+}
+
+func (s *SongSuite) TestSongBatchEmpty() {
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodPost, "/songs/batch", models.SongBatchRequest{})
+	s.Require().NoError(err)
+
+	s.Require().Equal(int64(http.StatusUnprocessableEntity), errResp.Status)
+	s.Require().Equal(models.ValidationFailed, errResp.Type)
+}
@@ -0,0 +1,57 @@
+package integration_tests
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/salmon822/test_task/models"
+)
+
+func (s *SongSuite) TestExportSongs() {
+	body := "groupName,songTitle,releaseDate,songText\n" +
+		"Muse,Starlight,2006-09-02,\"Far away\nThis ship is taking me far away\"\n" +
+		"Muse,Uprising,2009-09-07,\n" +
+		"Queen,Bohemian Rhapsody,1975-10-31,\n"
+	code, _ := s.importSongs("/songs/import?format=csv", body)
+	s.Require().Equal(http.StatusOK, code)
+
+	code, data, err := makeTextRequest(s.httpHandler, http.MethodGet, "/songs/export?format=csv&groupName=Muse&sort=-releaseDate", "")
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, code, data)
+
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	s.Require().NoError(err)
+	s.Require().Len(records, 3)
+	s.Require().Equal("id", records[0][0])
+	s.Require().Equal("Uprising", records[1][2])
+	s.Require().Equal("2006-09-02", records[2][3])
+	s.Require().Equal("Far away\nThis ship is taking me far away", records[2][4])
+
+	code, data, err = makeTextRequest(s.httpHandler, http.MethodGet, "/songs/export?format=ndjson&sort=songTitle", "")
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, code, data)
+
+	lines := strings.Split(strings.TrimSpace(data), "\n")
+	s.Require().Len(lines, 3)
+	var song models.Song
+	s.Require().NoError(json.Unmarshal([]byte(lines[0]), &song))
+	s.Require().Equal("Bohemian Rhapsody", song.SongTitle)
+
+	var songs []models.Song
+	code, data, err = makeTextRequest(s.httpHandler, http.MethodGet, "/songs/export?groupName=Nobody", "")
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, code, data)
+	s.Require().NoError(json.Unmarshal([]byte(data), &songs))
+	s.Require().Empty(songs)
+}
+
+func (s *SongSuite) TestExportSongsInvalidFormat() {
+	var errResp models.ErrorResponse
+	code, data, err := makeTextRequest(s.httpHandler, http.MethodGet, "/songs/export?format=xml", "")
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusBadRequest, code)
+	s.Require().NoError(json.Unmarshal([]byte(data), &errResp))
+	s.Require().Equal(models.InvalidRequest, errResp.Type)
+}
@@ -3,6 +3,7 @@ package song_helpers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/salmon822/test_task/internal/db"
 )
@@ -10,11 +11,14 @@ import (
 type songOptions struct {
 	groupName   *string
 	songTitle   *string
-	releaseDate *int64
+	releaseDate *time.Time
 	songText    *string
 	link        *string
 	createdAt   *int64
 	updatedAt   *int64
+	albumId     *int64
+	discNumber  *int64
+	trackNumber *int64
 }
 
 type SongOption func(options *songOptions) error
@@ -23,13 +27,12 @@ func getDefaultSongOptions() songOptions {
 	defaultString := ""
 	defaultInt64 := int64(0)
 	return songOptions{
-		groupName:   &defaultString,
-		songTitle:   &defaultString,
-		releaseDate: &defaultInt64,
-		songText:    &defaultString,
-		link:        &defaultString,
-		createdAt:   &defaultInt64,
-		updatedAt:   &defaultInt64,
+		groupName: &defaultString,
+		songTitle: &defaultString,
+		songText:  &defaultString,
+		link:      &defaultString,
+		createdAt: &defaultInt64,
+		updatedAt: &defaultInt64,
 	}
 }
 
@@ -63,6 +66,29 @@ func WithGroupName(groupName string) SongOption {
 	}
 }
 
+func WithReleaseDate(releaseDate string) SongOption {
+	return func(options *songOptions) error {
+		date, err := time.Parse(time.DateOnly, releaseDate)
+		if err != nil {
+			return err
+		}
+		options.releaseDate = &date
+		return nil
+	}
+}
+
+func WithAlbumTrack(albumId, discNumber, trackNumber int64) SongOption {
+	return func(options *songOptions) error {
+		if albumId <= 0 || discNumber <= 0 || trackNumber <= 0 {
+			return fmt.Errorf("invalid album track %d %d-%d", albumId, discNumber, trackNumber)
+		}
+		options.albumId = &albumId
+		options.discNumber = &discNumber
+		options.trackNumber = &trackNumber
+		return nil
+	}
+}
+
 func CreateSong(ctx context.Context, pgClient *db.PostgresClient, options ...SongOption) (int64, error) {
 	songOptions := getDefaultSongOptions()
 	for _, option := range options {
@@ -72,17 +98,108 @@ func CreateSong(ctx context.Context, pgClient *db.PostgresClient, options ...Son
 		}
 	}
 
+	artistId, err := CreateArtist(ctx, pgClient, *songOptions.groupName)
+	if err != nil {
+		return 0, err
+	}
+
 	query := `
-		INSERT INTO songs (id, group_name, song_title, release_date, song_text, link, created_at, updated_at)
-		VALUES (default, $1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO songs (id, artist_id, song_title, release_date, song_text, link, created_at, updated_at, album_id, disc_number, track_number)
+		VALUES (default, $1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id
 	`
-	row := pgClient.DB.QueryRowContext(ctx, query, *songOptions.groupName, *songOptions.songTitle, *songOptions.releaseDate, *songOptions.songText, *songOptions.link, *songOptions.createdAt, *songOptions.updatedAt)
+	row := pgClient.DB.QueryRowContext(ctx, query, artistId, *songOptions.songTitle, songOptions.releaseDate, *songOptions.songText, *songOptions.link, *songOptions.createdAt, *songOptions.updatedAt, songOptions.albumId, songOptions.discNumber, songOptions.trackNumber)
 	var songId int64
-	err := row.Scan(&songId)
+	err = row.Scan(&songId)
 	if err != nil {
 		return 0, err
 	}
 
 	return songId, nil
 }
+
+// CreateArtist returns the id of the artist with the given name, creating it
+// when it does not exist yet.
+func CreateArtist(ctx context.Context, pgClient *db.PostgresClient, name string) (int64, error) {
+	query := `
+		INSERT INTO artists (id, name, created_at, updated_at)
+		VALUES (default, $1, 0, 0)
+		ON CONFLICT ((lower(regexp_replace(btrim(name), '\s+', ' ', 'g')))) DO UPDATE SET name = artists.name
+		RETURNING id
+	`
+	row := pgClient.DB.QueryRowContext(ctx, query, name)
+	var artistId int64
+	err := row.Scan(&artistId)
+	if err != nil {
+		return 0, err
+	}
+
+	return artistId, nil
+}
+
+func CreateAlbum(ctx context.Context, pgClient *db.PostgresClient, groupName string, title string) (int64, error) {
+	artistId, err := CreateArtist(ctx, pgClient, groupName)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		INSERT INTO albums (id, artist_id, title, release_date, cover_link, created_at, updated_at)
+		VALUES (default, $1, $2, NULL, '', 0, 0)
+		RETURNING id
+	`
+	row := pgClient.DB.QueryRowContext(ctx, query, artistId, title)
+	var albumId int64
+	err = row.Scan(&albumId)
+	if err != nil {
+		return 0, err
+	}
+
+	return albumId, nil
+}
+
+func AddSongTag(ctx context.Context, pgClient *db.PostgresClient, songId int64, name string, kind string) (int64, error) {
+	query := `
+		WITH tag AS (
+			INSERT INTO tags (id, name, kind)
+			VALUES (default, $2, $3)
+			ON CONFLICT (kind, (lower(regexp_replace(btrim(name), '\s+', ' ', 'g')))) DO UPDATE SET name = tags.name
+			RETURNING id
+		)
+		INSERT INTO song_tags (song_id, tag_id)
+		SELECT $1, id FROM tag
+		RETURNING tag_id
+	`
+	row := pgClient.DB.QueryRowContext(ctx, query, songId, name, kind)
+	var tagId int64
+	err := row.Scan(&tagId)
+	if err != nil {
+		return 0, err
+	}
+
+	return tagId, nil
+}
+
+type SongEnrichment struct {
+	Status        string
+	Attempts      int64
+	LastError     string
+	NextAttemptAt int64
+}
+
+func GetSongEnrichment(ctx context.Context, pgClient *db.PostgresClient, id int64) (*SongEnrichment, error) {
+	query := `
+		SELECT enrichment_status, enrichment_attempts, enrichment_last_error, enrichment_next_attempt_at
+		FROM songs
+		WHERE id = $1
+	`
+	row := pgClient.DB.QueryRowContext(ctx, query, id)
+
+	var enrichment SongEnrichment
+	err := row.Scan(&enrichment.Status, &enrichment.Attempts, &enrichment.LastError, &enrichment.NextAttemptAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &enrichment, nil
+}
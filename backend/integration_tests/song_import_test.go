@@ -0,0 +1,107 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/salmon822/test_task/models"
+)
+
+func (s *SongSuite) importSongs(url string, body string) (int, models.SongImportReport) {
+	code, data, err := makeTextRequest(s.httpHandler, http.MethodPost, url, body)
+	s.Require().NoError(err)
+
+	var report models.SongImportReport
+	s.Require().NoError(json.Unmarshal([]byte(data), &report), data)
+
+	return code, report
+}
+
+func (s *SongSuite) TestImportSongsCSV() {
+	body := "groupName,songTitle,releaseDate,songText\n" +
+		"Muse,Starlight,2006-09-02,\"Far away\nThis ship is taking me far away\"\n" +
+		"Muse,Uprising,2009-09-07,\n"
+
+	code, report := s.importSongs("/songs/import?format=csv", body)
+	s.Require().Equal(http.StatusOK, code)
+
+	s.Require().True(report.Committed)
+	s.Require().Equal(models.Atomic, report.Mode)
+	s.Require().Equal(int64(2), report.Total)
+	s.Require().Equal(int64(2), report.Imported)
+	s.Require().Equal(int64(2), report.Rows[0].Line)
+	s.Require().Equal(int64(4), report.Rows[1].Line)
+	s.Require().Equal(models.SongImportRowStatusImported, report.Rows[1].Status)
+	s.Require().NotNil(report.Rows[1].Id)
+
+	var list models.SongListResponse
+	_, err := makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter?sort=releaseDate", nil, &list)
+	s.Require().NoError(err)
+	s.Require().Len(list.Items, 2)
+	s.Require().Equal("Starlight", list.Items[0].SongTitle)
+	s.Require().Equal(makePointer(models.Pending), list.Items[1].EnrichmentStatus)
+}
+
+func (s *SongSuite) TestImportSongsAtomicFailure() {
+	body := `{"groupName": "Muse", "songTitle": "Starlight"}` + "\n" +
+		`{"groupName": "", "songTitle": "Uprising"}` + "\n" +
+		"\n" +
+		`{"groupName": "Muse"` + "\n"
+
+	code, report := s.importSongs("/songs/import?format=ndjson", body)
+	s.Require().Equal(http.StatusUnprocessableEntity, code)
+
+	s.Require().False(report.Committed)
+	s.Require().Equal(int64(3), report.Total)
+	s.Require().Equal(int64(0), report.Imported)
+	s.Require().Equal(int64(2), report.Failed)
+	s.Require().Equal(models.SongImportRowStatusSkipped, report.Rows[0].Status)
+	s.Require().Nil(report.Rows[0].Id)
+	s.Require().Equal(int64(2), report.Rows[1].Line)
+	s.Require().Equal(models.SongImportRowStatusFailed, report.Rows[1].Status)
+	s.Require().Contains(*report.Rows[1].Error, "groupName")
+	s.Require().Equal(int64(4), report.Rows[2].Line)
+
+	var list models.SongListResponse
+	_, err := makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter", nil, &list)
+	s.Require().NoError(err)
+	s.Require().Empty(list.Items)
+}
+
+func (s *SongSuite) TestImportSongsBestEffort() {
+	var album models.Album
+	_, err := makeJsonRequest(s.httpHandler, http.MethodPost, "/albums/create",
+		models.AlbumCreateRequest{Album: &models.Album{GroupName: "Muse", Title: "Black Holes and Revelations"}}, &album)
+	s.Require().NoError(err)
+
+	// The last two rows take the same position on the album.
+	body := "groupName,songTitle,albumId,trackNumber\n" +
+		"Muse,Take a Bow,1,1\n" +
+		"Muse,Starlight,1,2\n" +
+		"Muse,Supermassive Black Hole,1,2\n"
+
+	code, report := s.importSongs("/songs/import?format=csv&mode=bestEffort&dryRun=true", body)
+	s.Require().Equal(http.StatusOK, code)
+	s.Require().True(report.DryRun)
+	s.Require().False(report.Committed)
+	s.Require().Equal(int64(2), report.Imported)
+	s.Require().Nil(report.Rows[0].Id)
+
+	var list models.SongListResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter", nil, &list)
+	s.Require().NoError(err)
+	s.Require().Empty(list.Items)
+
+	code, report = s.importSongs("/songs/import?format=csv&mode=bestEffort", body)
+	s.Require().Equal(http.StatusOK, code)
+	s.Require().True(report.Committed)
+	s.Require().Equal(int64(2), report.Imported)
+	s.Require().Equal(int64(1), report.Failed)
+	s.Require().Equal(models.SongImportRowStatusImported, report.Rows[1].Status)
+	s.Require().Equal(models.SongImportRowStatusFailed, report.Rows[2].Status)
+	s.Require().Equal(int64(4), report.Rows[2].Line)
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter", nil, &list)
+	s.Require().NoError(err)
+	s.Require().Len(list.Items, 2)
+}
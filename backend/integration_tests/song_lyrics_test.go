@@ -0,0 +1,123 @@
+package integration_tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/salmon822/test_task/integration_tests/song_helpers"
+	"github.com/salmon822/test_task/models"
+)
+
+type SongLyricsSuite struct {
+	TestSuite
+}
+
+func (s *SongLyricsSuite) SetupSuite() {
+	s.TestSuite.SetupSuite()
+}
+
+func (s *SongLyricsSuite) TestSongTranslationsCRUD() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongText("Far away\nThis ship is taking me far away"))
+	s.Require().NoError(err)
+
+	url := fmt.Sprintf("/songs/%d/lyrics/translations/ru", createdSong)
+
+	var translation models.SongLyrics
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPut, url,
+		models.SongLyricsRequest{SongText: "Далеко\nЭтот корабль уносит меня далеко"}, &translation)
+	s.Require().NoError(err)
+	s.Require().Equal(createdSong, translation.SongId)
+	s.Require().Equal("ru", translation.Language)
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPut, fmt.Sprintf("/songs/%d/lyrics/translations/en-us", createdSong),
+		models.SongLyricsRequest{SongText: "Far, far away"}, &translation)
+	s.Require().NoError(err)
+	s.Require().Equal("en-US", translation.Language)
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPut, url,
+		models.SongLyricsRequest{SongText: "Вдали\nЭтот корабль уносит меня вдаль"}, &translation)
+	s.Require().NoError(err)
+
+	var translations []models.SongLyrics
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, fmt.Sprintf("/songs/%d/lyrics/translations", createdSong), nil, &translations)
+	s.Require().NoError(err)
+	s.Require().Len(translations, 2)
+	s.Require().Equal("en-US", translations[0].Language)
+	s.Require().Equal("ru", translations[1].Language)
+	s.Require().Equal("Вдали\nЭтот корабль уносит меня вдаль", translations[1].SongText)
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodDelete, url, nil, nil)
+	s.Require().NoError(err)
+
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodGet, url, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusNotFound), errResp.Status)
+
+	errResp, err = makeJsonRequestWithErrorResp(s.httpHandler, http.MethodDelete, url, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusNotFound), errResp.Status)
+}
+
+func (s *SongLyricsSuite) TestPutSongTranslationInvalid() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient)
+	s.Require().NoError(err)
+
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodPut,
+		fmt.Sprintf("/songs/%d/lyrics/translations/%%21%%21", createdSong), models.SongLyricsRequest{SongText: "Text"})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusBadRequest), errResp.Status)
+
+	errResp, err = makeJsonRequestWithErrorResp(s.httpHandler, http.MethodPut,
+		fmt.Sprintf("/songs/%d/lyrics/translations/ru", createdSong), models.SongLyricsRequest{SongText: " "})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusUnprocessableEntity), errResp.Status)
+}
+
+func (s *SongLyricsSuite) TestGetSongTextTranslation() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient,
+		song_helpers.WithSongText("Far away\nThis ship is taking me far away\n\nMy life\nYou electrify my life"))
+	s.Require().NoError(err)
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPut, fmt.Sprintf("/songs/%d/lyrics/translations/ru", createdSong),
+		models.SongLyricsRequest{SongText: "Далеко\nЭтот корабль уносит меня далеко\n\nМоя жизнь\nТы наполняешь током мою жизнь"}, nil)
+	s.Require().NoError(err)
+
+	url := fmt.Sprintf("/songs/%d/song-text?page=2&pageSize=2", createdSong)
+
+	var songTextRes models.SongWithVerses
+
+	header, err := makeJsonRequestWithHeaders(s.httpHandler, http.MethodPost, url+"&lang=ru", nil, nil, &songTextRes)
+	s.Require().NoError(err)
+	s.Require().Equal("ru", header.Get("Content-Language"))
+	s.Require().Equal(makePointer("ru"), songTextRes.Language)
+	s.Require().Equal(int64(4), songTextRes.TotalVerses)
+	s.Require().Equal([]string{"Моя жизнь", "Ты наполняешь током мою жизнь"}, *songTextRes.Verses)
+
+	songTextRes = models.SongWithVerses{}
+	header, err = makeJsonRequestWithHeaders(s.httpHandler, http.MethodPost, url, nil,
+		map[string]string{"Accept-Language": "de-DE, ru-RU;q=0.8, en;q=0.5"}, &songTextRes)
+	s.Require().NoError(err)
+	s.Require().Equal("ru", header.Get("Content-Language"))
+	s.Require().Equal(makePointer("ru"), songTextRes.Language)
+
+	songTextRes = models.SongWithVerses{}
+	header, err = makeJsonRequestWithHeaders(s.httpHandler, http.MethodPost, url+"&lang=de", nil,
+		map[string]string{"Accept-Language": "ru"}, &songTextRes)
+	s.Require().NoError(err)
+	s.Require().Empty(header.Get("Content-Language"))
+	s.Require().Nil(songTextRes.Language)
+	s.Require().Equal([]string{"My life", "You electrify my life"}, *songTextRes.Verses)
+
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodPost, url+"&lang=%21%21", nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusBadRequest), errResp.Status)
+}
@@ -0,0 +1,123 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/salmon822/test_task/models"
+)
+
+func (s *SongSuite) createPatchedSong() models.Song {
+	req := models.SongCreateRequest{
+		Song: &models.Song{
+			GroupName:   "Muse",
+			SongTitle:   "Supermassive Black Hole",
+			ReleaseDate: makeDate("2006-07-16"),
+			SongText:    "Ooh baby, don't you know I suffer?",
+			Link:        "http://testlink.com",
+		},
+	}
+	var created models.Song
+	_, err := makeJsonRequest(s.httpHandler, http.MethodPost, "/songs/create", req, &created)
+	s.Require().NoError(err)
+
+	return created
+}
+
+func (s *SongSuite) TestMergePatchSong() {
+	created := s.createPatchedSong()
+	url := fmt.Sprintf("/songs/%d", created.Id)
+
+	var patched models.Song
+	header, err := makeJsonRequestWithHeaders(s.httpHandler, http.MethodPatch, url,
+		json.RawMessage(`{"link": null, "songText": "", "releaseDate": 0}`),
+		map[string]string{"Content-Type": "application/merge-patch+json", "If-Match": `"1"`}, &patched)
+	s.Require().NoError(err)
+	s.Require().Equal(`"2"`, header.Get("ETag"))
+
+	s.Require().Equal("Supermassive Black Hole", patched.SongTitle)
+	s.Require().Empty(patched.Link)
+	s.Require().Empty(patched.SongText)
+	s.Require().Nil(patched.ReleaseDate)
+
+	_, err = makeJsonRequestWithHeaders(s.httpHandler, http.MethodPatch, url,
+		json.RawMessage(`{"releaseDate": "2006-07-16"}`),
+		map[string]string{"Content-Type": "application/merge-patch+json"}, &patched)
+	s.Require().NoError(err)
+	s.Require().Equal(makeDate("2006-07-16"), patched.ReleaseDate)
+
+	_, err = makeJsonRequestWithHeaders(s.httpHandler, http.MethodPatch, url,
+		json.RawMessage(`{"releaseDate": null}`),
+		map[string]string{"Content-Type": "application/merge-patch+json"}, &patched)
+	s.Require().NoError(err)
+	s.Require().Nil(patched.ReleaseDate)
+
+	errResp, err := makeJsonRequestWithHeadersErrorResp(s.httpHandler, http.MethodPatch, url,
+		json.RawMessage(`{"songTitle": null, "id": 7}`),
+		map[string]string{"Content-Type": "application/merge-patch+json"})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusUnprocessableEntity), errResp.Status)
+	s.Require().NotNil(errResp.Errors)
+
+	var pointers []string
+	for _, field := range *errResp.Errors {
+		pointers = append(pointers, field.Pointer)
+	}
+	s.Require().Equal([]string{"/id", "/songTitle"}, pointers)
+}
+
+func (s *SongSuite) TestJSONPatchSong() {
+	created := s.createPatchedSong()
+	url := fmt.Sprintf("/songs/%d", created.Id)
+	headers := map[string]string{"Content-Type": "application/json-patch+json"}
+
+	var patched models.Song
+	_, err := makeJsonRequestWithHeaders(s.httpHandler, http.MethodPatch, url, json.RawMessage(`[
+		{"op": "test", "path": "/songTitle", "value": "Supermassive Black Hole"},
+		{"op": "replace", "path": "/songTitle", "value": "Starlight"},
+		{"op": "remove", "path": "/link"}
+	]`), headers, &patched)
+	s.Require().NoError(err)
+	s.Require().Equal("Starlight", patched.SongTitle)
+	s.Require().Empty(patched.Link)
+	s.Require().Equal(created.SongText, patched.SongText)
+
+	errResp, err := makeJsonRequestWithHeadersErrorResp(s.httpHandler, http.MethodPatch, url, json.RawMessage(`[
+		{"op": "test", "path": "/songTitle", "value": "Supermassive Black Hole"},
+		{"op": "replace", "path": "/songTitle", "value": "Uprising"}
+	]`), headers)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusConflict), errResp.Status)
+
+	errResp, err = makeJsonRequestWithHeadersErrorResp(s.httpHandler, http.MethodPatch, url,
+		json.RawMessage(`[{"op": "replace", "path": "/createdAt", "value": 0}]`), headers)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusUnprocessableEntity), errResp.Status)
+	s.Require().Equal("/createdAt", (*errResp.Errors)[0].Pointer)
+
+	errResp, err = makeJsonRequestWithHeadersErrorResp(s.httpHandler, http.MethodPatch, url,
+		json.RawMessage(`[{"op": "jump", "path": "/songTitle"}]`), headers)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusBadRequest), errResp.Status)
+}
+
+func (s *SongSuite) TestPatchSongJSONBody() {
+	created := s.createPatchedSong()
+	url := fmt.Sprintf("/songs/%d", created.Id)
+
+	var patched models.Song
+	_, err := makeJsonRequestWithHeaders(s.httpHandler, http.MethodPatch, url,
+		json.RawMessage(`{"song": {"link": "", "songTitle": "Starlight"}}`),
+		map[string]string{"Content-Type": "application/json"}, &patched)
+	s.Require().NoError(err)
+	s.Require().Equal("Starlight", patched.SongTitle)
+	s.Require().Empty(patched.Link)
+	s.Require().Equal(created.SongText, patched.SongText)
+
+	errResp, err := makeJsonRequestWithHeadersErrorResp(s.httpHandler, http.MethodPatch, url,
+		json.RawMessage(`{"songTitle": "Starlight"}`), map[string]string{"Content-Type": "text/plain"})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusUnsupportedMediaType), errResp.Status)
+	s.Require().Equal(models.UnsupportedMediaType, errResp.Type)
+}
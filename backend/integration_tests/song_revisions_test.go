@@ -0,0 +1,136 @@
+package integration_tests
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/salmon822/test_task/models"
+)
+
+type SongRevisionsSuite struct {
+	TestSuite
+}
+
+func (s *SongRevisionsSuite) SetupSuite() {
+	s.TestSuite.SetupSuite()
+}
+
+func (s *SongRevisionsSuite) createSong() models.Song {
+	req := models.SongCreateRequest{
+		Song: &models.Song{
+			GroupName:   "Muse",
+			SongTitle:   "Supermassive Black Hole",
+			ReleaseDate: makeDate("2006-07-16"),
+			SongText:    "Ooh baby, don't you know I suffer?",
+			Link:        "http://testlink.com",
+		},
+	}
+	var song models.Song
+
+	_, err := makeJsonRequest(s.httpHandler, http.MethodPost, "/songs/create", req, &song)
+	s.Require().NoError(err)
+
+	return song
+}
+
+func (s *SongRevisionsSuite) TestGetSongRevisions() {
+	song := s.createSong()
+
+	req := models.SongUpdateRequest{
+		Song: &models.Song{
+			SongTitle: "Starlight",
+			Link:      "http://newlink.com",
+		},
+	}
+	_, err := makeJsonRequest(s.httpHandler, http.MethodPatch, fmt.Sprintf("/songs/%d/update", song.Id), req, nil)
+	s.Require().NoError(err)
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodDelete, fmt.Sprintf("/songs/%d/delete", song.Id), nil, nil)
+	s.Require().NoError(err)
+
+	var revisions []models.SongRevision
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, fmt.Sprintf("/songs/%d/revisions", song.Id), nil, &revisions)
+	s.Require().NoError(err)
+	s.Require().Len(revisions, 3)
+
+	s.Require().Equal(int64(1), revisions[0].Revision)
+	s.Require().Equal(models.SongRevisionActionCreate, revisions[0].Action)
+	s.Require().Len(revisions[0].Changes, 5)
+	s.Require().Equal(models.SongFieldChange{Field: "releaseDate", New: "2006-07-16"}, revisions[0].Changes[2])
+
+	s.Require().Equal(models.SongRevisionActionUpdate, revisions[1].Action)
+	s.Require().Equal([]models.SongFieldChange{
+		{Field: "songTitle", Old: "Supermassive Black Hole", New: "Starlight"},
+		{Field: "link", Old: "http://testlink.com", New: "http://newlink.com"},
+	}, revisions[1].Changes)
+	s.Require().Equal("Starlight", revisions[1].Song.SongTitle)
+
+	s.Require().Equal(models.SongRevisionActionDelete, revisions[2].Action)
+	s.Require().Len(revisions[2].Changes, 5)
+	s.Require().Equal("Starlight", revisions[2].Song.SongTitle)
+}
+
+func (s *SongRevisionsSuite) TestRestoreSongRevision() {
+	song := s.createSong()
+
+	req := models.SongUpdateRequest{
+		Song: &models.Song{
+			SongTitle: "Starlight",
+		},
+	}
+	_, err := makeJsonRequest(s.httpHandler, http.MethodPatch, fmt.Sprintf("/songs/%d/update", song.Id), req, nil)
+	s.Require().NoError(err)
+
+	var restored models.Song
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPost, fmt.Sprintf("/songs/%d/revisions/1/restore", song.Id), nil, &restored)
+	s.Require().NoError(err)
+	s.Require().Equal(song.Id, restored.Id)
+	s.Require().Equal("Supermassive Black Hole", restored.SongTitle)
+
+	var revisions []models.SongRevision
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, fmt.Sprintf("/songs/%d/revisions", song.Id), nil, &revisions)
+	s.Require().NoError(err)
+	s.Require().Len(revisions, 3)
+	s.Require().Equal(models.SongRevisionActionRestore, revisions[2].Action)
+	s.Require().Equal([]models.SongFieldChange{
+		{Field: "songTitle", Old: "Starlight", New: "Supermassive Black Hole"},
+	}, revisions[2].Changes)
+}
+
+func (s *SongRevisionsSuite) TestRestoreDeletedSong() {
+	song := s.createSong()
+
+	_, err := makeJsonRequest(s.httpHandler, http.MethodDelete, fmt.Sprintf("/songs/%d/delete", song.Id), nil, nil)
+	s.Require().NoError(err)
+
+	var restored models.Song
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPost, fmt.Sprintf("/songs/%d/revisions/2/restore", song.Id), nil, &restored)
+	s.Require().NoError(err)
+	s.Require().Equal(song.Id, restored.Id)
+	s.Require().Equal(song.SongTitle, restored.SongTitle)
+	s.Require().Equal(song.ReleaseDate, restored.ReleaseDate)
+
+	var songWithVerses models.SongWithVerses
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPost, fmt.Sprintf("/songs/%d/song-text", song.Id), nil, &songWithVerses)
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"Ooh baby, don't you know I suffer?"}, songWithVerses.Verses)
+}
+
+func (s *SongRevisionsSuite) TestRestoreMissingRevision() {
+	song := s.createSong()
+
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodPost, fmt.Sprintf("/songs/%d/revisions/5/restore", song.Id), nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusNotFound), errResp.Status)
+}
+
+func (s *SongRevisionsSuite) TestGetSongRevisionsNotFound() {
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodGet, "/songs/999999/revisions", nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusNotFound), errResp.Status)
+}
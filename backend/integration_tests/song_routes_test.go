@@ -0,0 +1,120 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/salmon822/test_task/models"
+)
+
+func (s *SongSuite) TestSongResourceRoutes() {
+	req := models.SongCreateRequest{
+		Song: &models.Song{
+			GroupName:   "Muse",
+			SongTitle:   "Starlight",
+			ReleaseDate: makeDate("2006-09-04"),
+			SongText:    "Far away\nThis ship is taking me far away",
+			Link:        "http://testlink.com",
+		},
+	}
+	var created models.Song
+	header, err := makeJsonRequestWithHeaders(s.httpHandler, http.MethodPost, "/api/v1/songs", req, nil, &created)
+	s.Require().NoError(err)
+	s.Require().Empty(header.Get("Deprecation"))
+	url := fmt.Sprintf("/api/v1/songs/%d", created.Id)
+
+	var list models.SongListResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/api/v1/songs?songTitle=Starlight", nil, &list)
+	s.Require().NoError(err)
+	s.Require().Len(list.Items, 1)
+	s.Require().Equal(created.Id, list.Items[0].Id)
+
+	var patched models.Song
+	_, err = makeJsonRequestWithHeaders(s.httpHandler, http.MethodPatch, url,
+		json.RawMessage(`{"link": null}`),
+		map[string]string{"Content-Type": "application/merge-patch+json"}, &patched)
+	s.Require().NoError(err)
+	s.Require().Empty(patched.Link)
+
+	var text models.SongWithVerses
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, url+"/text?pageSize=1", nil, &text)
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"Far away"}, *text.Verses)
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodDelete, url, nil, nil)
+	s.Require().NoError(err)
+
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodGet, url+"/text", nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusNotFound), errResp.Status)
+}
+
+func (s *SongSuite) TestLegacySongRoutesAreDeprecated() {
+	req := models.SongCreateRequest{
+		Song: &models.Song{
+			GroupName: "Muse",
+			SongTitle: "Uprising",
+			SongText:  "Paranoia is in bloom",
+		},
+	}
+	var created models.Song
+	header, err := makeJsonRequestWithHeaders(s.httpHandler, http.MethodPost, "/songs/create", req, nil, &created)
+	s.Require().NoError(err)
+	s.Require().Equal("@1792281600", header.Get("Deprecation"))
+	s.Require().Equal("Sun, 18 Apr 2027 00:00:00 GMT", header.Get("Sunset"))
+	s.Require().Equal(`</api/v1/songs>; rel="successor-version"`, header.Get("Link"))
+
+	header, err = makeJsonRequestWithHeaders(s.httpHandler, http.MethodPost,
+		fmt.Sprintf("/songs/%d/song-text", created.Id), nil, nil, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(fmt.Sprintf(`</api/v1/songs/%d/text>; rel="successor-version"`, created.Id), header.Get("Link"))
+
+	header, err = makeJsonRequestWithHeaders(s.httpHandler, http.MethodGet,
+		fmt.Sprintf("/api/v1/songs/%d/tags", created.Id), nil, nil, nil)
+	s.Require().NoError(err)
+	s.Require().Empty(header.Get("Deprecation"))
+}
+
+func (s *SongSuite) TestGetSong() {
+	req := models.SongCreateRequest{
+		Song: &models.Song{
+			GroupName:   "Muse",
+			SongTitle:   "Hysteria",
+			ReleaseDate: makeDate("2003-12-01"),
+			SongText:    "It's bugging me, grating me",
+			Link:        "http://testlink.com",
+		},
+	}
+	var created models.Song
+	_, err := makeJsonRequest(s.httpHandler, http.MethodPost, "/api/v1/songs", req, &created)
+	s.Require().NoError(err)
+	url := fmt.Sprintf("/api/v1/songs/%d", created.Id)
+
+	var song models.Song
+	header, err := makeJsonRequestWithHeaders(s.httpHandler, http.MethodGet, url, nil, nil, &song)
+	s.Require().NoError(err)
+	s.Require().Equal(`"1"`, header.Get("ETag"))
+	s.Require().Equal(created.Id, song.Id)
+	s.Require().Equal("It's bugging me, grating me", song.SongText)
+
+	var fields map[string]any
+	header, err = makeJsonRequestWithHeaders(s.httpHandler, http.MethodGet, url+"?fields=id,songTitle,albumId", nil, nil, &fields)
+	s.Require().NoError(err)
+	s.Require().Equal(`W/"1"`, header.Get("ETag"))
+	s.Require().Equal(map[string]any{"id": float64(created.Id), "songTitle": "Hysteria", "albumId": nil}, fields)
+
+	errResp, err := makeJsonRequestWithHeadersErrorResp(s.httpHandler, http.MethodPatch, url,
+		json.RawMessage(`{"link": null}`),
+		map[string]string{"Content-Type": "application/merge-patch+json", "If-Match": header.Get("ETag")})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusPreconditionFailed), errResp.Status)
+
+	errResp, err = makeJsonRequestWithErrorResp(s.httpHandler, http.MethodGet, url+"?fields=lyrics", nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusBadRequest), errResp.Status)
+
+	errResp, err = makeJsonRequestWithErrorResp(s.httpHandler, http.MethodGet, "/songs/999999", nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusNotFound), errResp.Status)
+}
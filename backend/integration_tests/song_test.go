@@ -2,11 +2,12 @@ package integration_tests
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/salmon822/test_task/integration_tests/song_helpers"
-	"github.com/salmon822/test_task/internal/domain"
 	"github.com/salmon822/test_task/models"
 )
 
@@ -22,7 +23,7 @@ func (s *SongSuite) TestCreateSongSuccess() {
 	songToCreate := &models.Song{
 		GroupName:   "Test Group",
 		SongTitle:   "Test Song",
-		ReleaseDate: 20220101,
+		ReleaseDate: makeDate("2022-01-01"),
 		SongText:    "Test song text",
 		Link:        "http://testlink.com",
 	}
@@ -33,6 +34,8 @@ func (s *SongSuite) TestCreateSongSuccess() {
 
 	expectedCreateRes := *songToCreate
 	expectedCreateRes.Id = 1
+	expectedCreateRes.ArtistId = makePointer(int64(1))
+	expectedCreateRes.EnrichmentStatus = makePointer(models.Enriched)
 
 	_, err := makeJsonRequest(s.httpHandler, http.MethodPost, "/songs/create", req, &songCreateRes)
 	s.Require().NoError(err)
@@ -40,6 +43,169 @@ func (s *SongSuite) TestCreateSongSuccess() {
 	s.Require().Equal(expectedCreateRes, songCreateRes)
 }
 
+func (s *SongSuite) TestCreateSongEnrichedFromMusicInfo() {
+	req := models.SongCreateRequest{
+		Song: &models.Song{
+			GroupName: fakeMusicInfoGroup,
+			SongTitle: fakeMusicInfoSong,
+		},
+	}
+	var songCreateRes models.Song
+
+	_, err := makeJsonRequest(s.httpHandler, http.MethodPost, "/songs/create", req, &songCreateRes)
+	s.Require().NoError(err)
+
+	s.Require().Equal(makeDate("2006-07-16"), songCreateRes.ReleaseDate)
+	s.Require().Equal(fakeMusicInfoDetail.Text, songCreateRes.SongText)
+	s.Require().Equal(fakeMusicInfoDetail.Link, songCreateRes.Link)
+	s.Require().Equal(makePointer(models.Enriched), songCreateRes.EnrichmentStatus)
+}
+
+func (s *SongSuite) TestCreateSongKeepsClientFieldsWhenEnriching() {
+	req := models.SongCreateRequest{
+		Song: &models.Song{
+			GroupName: fakeMusicInfoGroup,
+			SongTitle: fakeMusicInfoSong,
+			Link:      "http://customlink.com",
+		},
+	}
+	var songCreateRes models.Song
+
+	_, err := makeJsonRequest(s.httpHandler, http.MethodPost, "/songs/create", req, &songCreateRes)
+	s.Require().NoError(err)
+
+	s.Require().Equal("http://customlink.com", songCreateRes.Link)
+	s.Require().Equal(fakeMusicInfoDetail.Text, songCreateRes.SongText)
+}
+
+func (s *SongSuite) TestCreateSongMusicInfoUnavailable() {
+	req := models.SongCreateRequest{
+		Song: &models.Song{
+			GroupName: fakeMusicInfoBrokenGroup,
+			SongTitle: "Any Song",
+		},
+	}
+	var songCreateRes models.Song
+
+	_, err := makeJsonRequest(s.httpHandler, http.MethodPost, "/songs/create", req, &songCreateRes)
+	s.Require().NoError(err)
+
+	s.Require().NotZero(songCreateRes.Id)
+	s.Require().Empty(songCreateRes.SongText)
+	s.Require().Equal(makePointer(models.Pending), songCreateRes.EnrichmentStatus)
+}
+
+func (s *SongSuite) TestEnrichPendingSongs() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient,
+		song_helpers.WithGroupName(fakeMusicInfoGroup),
+		song_helpers.WithSongTitle(fakeMusicInfoSong))
+	s.Require().NoError(err)
+
+	enriched, err := s.services.EnrichPendingSongs(ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(1, enriched)
+
+	enrichment, err := song_helpers.GetSongEnrichment(ctx, s.pgClient, createdSong)
+	s.Require().NoError(err)
+	s.Require().Equal(string(models.Enriched), enrichment.Status)
+	s.Require().Equal(int64(1), enrichment.Attempts)
+	s.Require().Empty(enrichment.LastError)
+
+	var revisions []models.SongRevision
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, fmt.Sprintf("/songs/%d/revisions", createdSong), nil, &revisions)
+	s.Require().NoError(err)
+	s.Require().Len(revisions, 1)
+	s.Require().Equal(models.SongRevisionActionUpdate, revisions[0].Action)
+}
+
+func (s *SongSuite) TestEnrichPendingSongsBacksOffOnFailure() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient,
+		song_helpers.WithGroupName(fakeMusicInfoBrokenGroup),
+		song_helpers.WithSongTitle("Any Song"))
+	s.Require().NoError(err)
+
+	enriched, err := s.services.EnrichPendingSongs(ctx)
+	s.Require().NoError(err)
+	s.Require().Zero(enriched)
+
+	enrichment, err := song_helpers.GetSongEnrichment(ctx, s.pgClient, createdSong)
+	s.Require().NoError(err)
+	s.Require().Equal(string(models.Pending), enrichment.Status)
+	s.Require().Equal(int64(1), enrichment.Attempts)
+	s.Require().NotEmpty(enrichment.LastError)
+	s.Require().Greater(enrichment.NextAttemptAt, time.Now().Unix())
+
+	// The song is not picked up again until its backoff expires.
+	enriched, err = s.services.EnrichPendingSongs(ctx)
+	s.Require().NoError(err)
+	s.Require().Zero(enriched)
+
+	enrichment, err = song_helpers.GetSongEnrichment(ctx, s.pgClient, createdSong)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1), enrichment.Attempts)
+}
+
+func (s *SongSuite) TestEnrichPendingSongsWithBadReleaseDate() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient,
+		song_helpers.WithGroupName(fakeMusicInfoGroup),
+		song_helpers.WithSongTitle(fakeMusicInfoBadDateSong))
+	s.Require().NoError(err)
+
+	enriched, err := s.services.EnrichPendingSongs(ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(1, enriched)
+
+	// The text and link are kept, the date error is recorded without a retry.
+	enrichment, err := song_helpers.GetSongEnrichment(ctx, s.pgClient, createdSong)
+	s.Require().NoError(err)
+	s.Require().Equal(string(models.Enriched), enrichment.Status)
+	s.Require().Contains(enrichment.LastError, "summer 2006")
+
+	var song models.Song
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, fmt.Sprintf("/api/v1/songs/%d", createdSong), nil, &song)
+	s.Require().NoError(err)
+	s.Require().Nil(song.ReleaseDate)
+	s.Require().Equal(fakeMusicInfoBadDateDetail.Text, song.SongText)
+	s.Require().Equal(fakeMusicInfoBadDateDetail.Link, song.Link)
+}
+
+func (s *SongSuite) TestEnrichPendingSongsConcurrently() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient,
+		song_helpers.WithGroupName(fakeMusicInfoGroup),
+		song_helpers.WithSongTitle(fakeMusicInfoSong))
+	s.Require().NoError(err)
+
+	// Two workers running at once must not both claim the same song.
+	results := make(chan int, 2)
+	errs := make(chan error, 2)
+	for range 2 {
+		go func() {
+			enriched, err := s.services.EnrichPendingSongs(ctx)
+			results <- enriched
+			errs <- err
+		}()
+	}
+	total := 0
+	for range 2 {
+		s.Require().NoError(<-errs)
+		total += <-results
+	}
+	s.Require().Equal(1, total)
+
+	enrichment, err := song_helpers.GetSongEnrichment(ctx, s.pgClient, createdSong)
+	s.Require().NoError(err)
+	s.Require().Equal(string(models.Enriched), enrichment.Status)
+	s.Require().Equal(int64(1), enrichment.Attempts)
+}
+
 func (s *SongSuite) TestDeleteSongSuccess() {
 	ctx := context.Background()
 
@@ -57,6 +223,137 @@ func (s *SongSuite) TestDeleteSongSuccess() {
 	s.Require().Equal(expectedDeleteRes, successRes)
 }
 
+func (s *SongSuite) TestDeleteSongNotFound() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient)
+	s.Require().NoError(err)
+
+	url := fmt.Sprintf("/api/v1/songs/%d", createdSong)
+	_, err = makeJsonRequest(s.httpHandler, http.MethodDelete, url, nil, nil)
+	s.Require().NoError(err)
+
+	// A song already in the trash is not found either.
+	for _, url := range []string{url, "/api/v1/songs/999999"} {
+		errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodDelete, url, nil)
+		s.Require().NoError(err)
+		s.Require().Equal(int64(http.StatusNotFound), errResp.Status)
+	}
+}
+
+func (s *SongSuite) TestUpdateSongIfMatch() {
+	req := models.SongCreateRequest{
+		Song: &models.Song{
+			GroupName:   "Muse",
+			SongTitle:   "Supermassive Black Hole",
+			ReleaseDate: makeDate("2006-07-16"),
+			SongText:    "Ooh baby, don't you know I suffer?",
+			Link:        "http://testlink.com",
+		},
+	}
+	var created models.Song
+
+	header, err := makeJsonRequestWithHeaders(s.httpHandler, http.MethodPost, "/songs/create", req, nil, &created)
+	s.Require().NoError(err)
+	s.Require().Equal(`"1"`, header.Get("ETag"))
+
+	url := fmt.Sprintf("/songs/%d/update", created.Id)
+	update := models.SongUpdateRequest{Song: &models.Song{SongTitle: "Starlight"}}
+
+	header, err = makeJsonRequestWithHeaders(s.httpHandler, http.MethodPatch, url, update,
+		map[string]string{"If-Match": `"1"`}, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(`"2"`, header.Get("ETag"))
+
+	// The second editor still holds the first version.
+	update = models.SongUpdateRequest{Song: &models.Song{SongTitle: "Uprising"}}
+	errResp, err := makeJsonRequestWithHeadersErrorResp(s.httpHandler, http.MethodPatch, url, update,
+		map[string]string{"If-Match": `"1"`})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusPreconditionFailed), errResp.Status)
+	s.Require().Equal(models.PreconditionFailed, errResp.Type)
+
+	header, err = makeJsonRequestWithHeaders(s.httpHandler, http.MethodPost, fmt.Sprintf("/songs/%d/song-text", created.Id), nil, nil, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(`W/"2"`, header.Get("ETag"))
+
+	var updated models.Song
+	header, err = makeJsonRequestWithHeaders(s.httpHandler, http.MethodPatch, url, update,
+		map[string]string{"If-Match": `"2"`}, &updated)
+	s.Require().NoError(err)
+	s.Require().Equal("Uprising", updated.SongTitle)
+	s.Require().Equal(`"3"`, header.Get("ETag"))
+}
+
+func (s *SongSuite) TestDeletedSongInTrash() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient)
+	s.Require().NoError(err)
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodDelete, fmt.Sprintf("/songs/%d/delete", createdSong), nil, nil)
+	s.Require().NoError(err)
+
+	var list models.SongListResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter", nil, &list)
+	s.Require().NoError(err)
+	s.Require().Empty(list.Items)
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter?includeDeleted=true", nil, &list)
+	s.Require().NoError(err)
+	s.Require().Len(list.Items, 1)
+	s.Require().NotNil(list.Items[0].DeletedAt)
+
+	for _, path := range []string{"tags", "lyrics/translations", "lyrics/lrc", "lyrics/at?t=1"} {
+		errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodGet, fmt.Sprintf("/songs/%d/%s", createdSong, path), nil)
+		s.Require().NoError(err)
+		s.Require().Equal(int64(http.StatusNotFound), errResp.Status, path)
+	}
+
+	var restored models.Song
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPost, fmt.Sprintf("/songs/%d/restore", createdSong), nil, &restored)
+	s.Require().NoError(err)
+	s.Require().Equal(createdSong, restored.Id)
+	s.Require().Nil(restored.DeletedAt)
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter", nil, &list)
+	s.Require().NoError(err)
+	s.Require().Len(list.Items, 1)
+
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodPost, fmt.Sprintf("/songs/%d/restore", createdSong), nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusNotFound), errResp.Status)
+}
+
+func (s *SongSuite) TestPurgeDeletedSongs() {
+	ctx := context.Background()
+
+	oldSong, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongTitle("Old"))
+	s.Require().NoError(err)
+	recentSong, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongTitle("Recent"))
+	s.Require().NoError(err)
+
+	for _, id := range []int64{oldSong, recentSong} {
+		_, err = makeJsonRequest(s.httpHandler, http.MethodDelete, fmt.Sprintf("/songs/%d/delete", id), nil, nil)
+		s.Require().NoError(err)
+	}
+
+	retention := s.cfg.TrashPurgeWorker.Retention
+	_, err = s.pgClient.DB.ExecContext(ctx, `UPDATE songs SET deleted_at = $2 WHERE id = $1`,
+		oldSong, time.Now().Add(-retention-time.Hour).Unix())
+	s.Require().NoError(err)
+
+	purged, err := s.services.Trash.PurgeDeletedSongs(ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1), purged)
+
+	var list models.SongListResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter?includeDeleted=true", nil, &list)
+	s.Require().NoError(err)
+	s.Require().Len(list.Items, 1)
+	s.Require().Equal(recentSong, list.Items[0].Id)
+}
+
 func (s *SongSuite) TestUpdateSongSuccess() {
 	ctx := context.Background()
 
@@ -66,7 +363,7 @@ func (s *SongSuite) TestUpdateSongSuccess() {
 	songUpdate := &models.Song{
 		GroupName:   "Updated Group",
 		SongTitle:   "Updated Song",
-		ReleaseDate: 20220102,
+		ReleaseDate: makeDate("2022-01-02"),
 		SongText:    "Updated song text",
 		Link:        "http://updatedlink.com",
 	}
@@ -77,13 +374,65 @@ func (s *SongSuite) TestUpdateSongSuccess() {
 
 	expectedUpdateRes := *songUpdate
 	expectedUpdateRes.Id = createdSong
+	expectedUpdateRes.EnrichmentStatus = makePointer(models.Pending)
 
 	_, err = makeJsonRequest(s.httpHandler, http.MethodPatch, fmt.Sprintf("/songs/%d/update", createdSong), req, &songUpdateRes)
 	s.Require().NoError(err)
 
+	s.Require().NotNil(songUpdateRes.ArtistId)
+	expectedUpdateRes.ArtistId = songUpdateRes.ArtistId
+
 	s.Require().Equal(expectedUpdateRes, songUpdateRes)
 }
 
+func (s *SongSuite) TestUpdateSongNotFound() {
+	req := models.SongUpdateRequest{Song: &models.Song{SongTitle: "Starlight"}}
+
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodPatch, "/songs/42/update", req)
+	s.Require().NoError(err)
+
+	s.Require().Equal(int64(http.StatusNotFound), errResp.Status)
+	s.Require().Equal(models.NotFound, errResp.Type)
+}
+
+func (s *SongSuite) TestUpdateSongValidationProblem() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient)
+	s.Require().NoError(err)
+
+	url := fmt.Sprintf("/songs/%d/update", createdSong)
+	req := models.SongUpdateRequest{Song: &models.Song{Link: "not a link", TrackNumber: makePointer(int64(0))}}
+
+	errResp, err := makeJsonRequestWithHeadersErrorResp(s.httpHandler, http.MethodPatch, url, req,
+		map[string]string{"X-Request-ID": "req-42"})
+	s.Require().NoError(err)
+
+	s.Require().Equal(int64(http.StatusUnprocessableEntity), errResp.Status)
+	s.Require().Equal(models.ValidationFailed, errResp.Type)
+	s.Require().Equal("Validation failed", errResp.Title)
+	s.Require().Equal(makePointer(url), errResp.Instance)
+	s.Require().Equal(makePointer("req-42"), errResp.RequestId)
+	s.Require().NotNil(errResp.Errors)
+
+	var pointers []string
+	for _, fieldError := range *errResp.Errors {
+		pointers = append(pointers, fieldError.Pointer)
+	}
+	s.Require().Equal([]string{"/song/link", "/song/albumId"}, pointers)
+}
+
+func (s *SongSuite) TestCreateSongBlankGroupName() {
+	req := models.SongCreateRequest{Song: &models.Song{GroupName: "   ", SongTitle: "Starlight"}}
+
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodPost, "/api/v1/songs", req)
+	s.Require().NoError(err)
+
+	s.Require().Equal(int64(http.StatusUnprocessableEntity), errResp.Status)
+	s.Require().NotNil(errResp.Errors)
+	s.Require().Equal([]models.ErrorResponseField{{Pointer: "/song/groupName", Detail: "cannot be blank"}}, *errResp.Errors)
+}
+
 func (s *SongSuite) TestGetSongTextSuccess() {
 	ctx := context.Background()
 
@@ -92,12 +441,102 @@ func (s *SongSuite) TestGetSongTextSuccess() {
 
 	url := fmt.Sprintf("/songs/%d/song-text?page=1&pageSize=2", createdSong)
 
-	var songTextRes domain.SongWithVerses
+	var songTextRes models.SongWithVerses
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPost, url, nil, &songTextRes)
+	s.Require().NoError(err)
+
+	s.Require().Equal(models.SongWithVersesUnitLine, songTextRes.Unit)
+	s.Require().Equal(int64(4), songTextRes.TotalVerses)
+	s.Require().Equal(int64(2), songTextRes.TotalStanzas)
+	s.Require().NotNil(songTextRes.Verses)
+	s.Require().Equal([]string{"Verse 1 line 1", "Verse 1 line 2"}, *songTextRes.Verses)
+}
+
+func (s *SongSuite) TestGetSongTextByStanza() {
+	req := models.SongCreateRequest{
+		Song: &models.Song{
+			GroupName:   "Muse",
+			SongTitle:   "Starlight",
+			ReleaseDate: makeDate("2006-09-04"),
+			SongText: "[Verse 1]\nFar away\nThis ship is taking me far away\n\n" +
+				"[Chorus]\nMy life\nYou electrify my life\n\n" +
+				"[Verse 2]\nOur hopes and expectations\n\n" +
+				"[Chorus]",
+			Link: "http://starlight.com",
+		},
+	}
+	var songCreateRes models.Song
+
+	_, err := makeJsonRequest(s.httpHandler, http.MethodPost, "/songs/create", req, &songCreateRes)
+	s.Require().NoError(err)
+
+	url := fmt.Sprintf("/songs/%d/song-text?unit=stanza&page=2&pageSize=3", songCreateRes.Id)
+
+	var songTextRes models.SongWithVerses
 
 	_, err = makeJsonRequest(s.httpHandler, http.MethodPost, url, nil, &songTextRes)
 	s.Require().NoError(err)
 
-	s.Require().Equal(2, len(songTextRes.Verses))
+	s.Require().Equal(models.SongWithVersesUnitStanza, songTextRes.Unit)
+	s.Require().Equal(int64(4), songTextRes.TotalStanzas)
+	s.Require().Equal(int64(7), songTextRes.TotalVerses)
+	s.Require().Nil(songTextRes.Verses)
+	s.Require().NotNil(songTextRes.Stanzas)
+	s.Require().Equal([]models.Stanza{{
+		Number:  4,
+		Section: makePointer("chorus"),
+		Label:   makePointer("Chorus"),
+		Lines:   []string{"My life", "You electrify my life"},
+	}}, *songTextRes.Stanzas)
+}
+
+func (s *SongSuite) TestGetSongTextByStanzaWithoutMarkers() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongText("Verse 1 line 1\nVerse 1 line 2\n\nVerse 2 line 1"))
+	s.Require().NoError(err)
+
+	url := fmt.Sprintf("/songs/%d/song-text?unit=stanza", createdSong)
+
+	var songTextRes models.SongWithVerses
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPost, url, nil, &songTextRes)
+	s.Require().NoError(err)
+
+	s.Require().NotNil(songTextRes.Stanzas)
+	s.Require().Equal([]models.Stanza{
+		{Number: 1, Lines: []string{"Verse 1 line 1", "Verse 1 line 2"}},
+		{Number: 2, Lines: []string{"Verse 2 line 1"}},
+	}, *songTextRes.Stanzas)
+}
+
+func (s *SongSuite) TestGetSongTextInvalidUnit() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongText("Verse 1 line 1"))
+	s.Require().NoError(err)
+
+	url := fmt.Sprintf("/songs/%d/song-text?unit=word", createdSong)
+
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodPost, url, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusBadRequest), errResp.Status)
+}
+
+func (s *SongSuite) TestGetSongTextInvalidPage() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongText("Verse 1 line 1"))
+	s.Require().NoError(err)
+
+	for _, query := range []string{"page=0", "page=-1", "pageSize=0", "pageSize=-5"} {
+		url := fmt.Sprintf("/songs/%d/song-text?%s", createdSong, query)
+
+		errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodPost, url, nil)
+		s.Require().NoError(err)
+		s.Require().Equal(int64(http.StatusBadRequest), errResp.Status, query)
+	}
 }
 
 func (s *SongSuite) TestGetFilteredSongsSuccess() {
@@ -115,13 +554,430 @@ func (s *SongSuite) TestGetFilteredSongsSuccess() {
 
 	url := "/songs/filter?groupName=TestGroup&songTitle=TestSong"
 
-	var filteredSongs []models.Song
+	var filteredSongs models.SongListResponse
 
 	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, url, nil, &filteredSongs)
 	s.Require().NoError(err)
 
-	s.Require().NotEmpty(filteredSongs)
+	s.Require().NotEmpty(filteredSongs.Items)
+
+	s.Require().Equal("TestGroup", filteredSongs.Items[0].GroupName)
+	s.Require().Equal("TestSong", filteredSongs.Items[0].SongTitle)
+}
+
+func (s *SongSuite) TestGetFilteredSongsPageMetadata() {
+	ctx := context.Background()
+
+	for i := 1; i <= 5; i++ {
+		_, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongTitle(fmt.Sprintf("Song%d", i)))
+		s.Require().NoError(err)
+	}
+
+	var songs models.SongListResponse
+	header, err := makeJsonRequestWithHeaders(s.httpHandler, http.MethodGet, "/songs/filter?page=2&pageSize=2", nil, nil, &songs)
+	s.Require().NoError(err)
+
+	s.Require().Len(songs.Items, 2)
+	s.Require().Equal(makePointer(int64(2)), songs.Page)
+	s.Require().Equal(int64(2), songs.PageSize)
+	s.Require().Equal(int64(5), songs.TotalItems)
+	s.Require().Equal(int64(3), songs.TotalPages)
+
+	link := header.Get("Link")
+	s.Require().Contains(link, `</songs/filter?page=3&pageSize=2>; rel="next"`)
+	s.Require().Contains(link, `</songs/filter?page=1&pageSize=2>; rel="prev"`)
+	s.Require().Contains(link, `</songs/filter?page=3&pageSize=2>; rel="last"`)
+}
+
+func (s *SongSuite) TestGetFilteredSongsCursorPagination() {
+	ctx := context.Background()
+
+	for i := 1; i <= 5; i++ {
+		_, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongTitle(fmt.Sprintf("Song%d", i)))
+		s.Require().NoError(err)
+	}
+
+	var firstPage models.SongListResponse
+	_, err := makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter?pageSize=2", nil, &firstPage)
+	s.Require().NoError(err)
+
+	s.Require().Len(firstPage.Items, 2)
+	s.Require().Equal("Song1", firstPage.Items[0].SongTitle)
+	s.Require().Nil(firstPage.PrevCursor)
+	s.Require().NotNil(firstPage.NextCursor)
+
+	// Deleting a song already seen must not shift the following pages.
+	_, err = makeJsonRequest(s.httpHandler, http.MethodDelete, fmt.Sprintf("/songs/%d/delete", firstPage.Items[0].Id), nil, nil)
+	s.Require().NoError(err)
+
+	var secondPage models.SongListResponse
+	url := "/songs/filter?pageSize=2&after=" + *firstPage.NextCursor
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, url, nil, &secondPage)
+	s.Require().NoError(err)
+
+	s.Require().Len(secondPage.Items, 2)
+	s.Require().Equal("Song3", secondPage.Items[0].SongTitle)
+	s.Require().Equal("Song4", secondPage.Items[1].SongTitle)
+	s.Require().NotNil(secondPage.PrevCursor)
+	s.Require().NotNil(secondPage.NextCursor)
+
+	var lastPage models.SongListResponse
+	url = "/songs/filter?pageSize=2&after=" + *secondPage.NextCursor
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, url, nil, &lastPage)
+	s.Require().NoError(err)
+
+	s.Require().Len(lastPage.Items, 1)
+	s.Require().Equal("Song5", lastPage.Items[0].SongTitle)
+	s.Require().Nil(lastPage.NextCursor)
+
+	var prevPage models.SongListResponse
+	url = "/songs/filter?pageSize=2&before=" + *lastPage.PrevCursor
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, url, nil, &prevPage)
+	s.Require().NoError(err)
+
+	s.Require().Len(prevPage.Items, 2)
+	s.Require().Equal("Song3", prevPage.Items[0].SongTitle)
+	s.Require().Equal("Song4", prevPage.Items[1].SongTitle)
+}
+
+func (s *SongSuite) TestGetFilteredSongsInvalidCursor() {
+	_, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodGet, "/songs/filter?after=not-a-cursor", nil)
+	s.Require().NoError(err)
+}
+
+func (s *SongSuite) TestGetFilteredSongsSorted() {
+	ctx := context.Background()
+
+	songs := []struct {
+		groupName   string
+		songTitle   string
+		releaseDate string
+	}{
+		{"Muse", "Uprising", "2009-09-07"},
+		{"Kino", "Gruppa krovi", "1988-01-01"},
+		{"Blur", "Song 2", "1997-04-07"},
+		{"Abba", "Mamma Mia", "1975-01-01"},
+		{"Coldplay", "Yellow", "2000-06-26"},
+		{"Arctic Monkeys", "505", "2007-04-23"},
+		{"Aerosmith", "Dream On", "1973-06-27"},
+	}
+	for _, song := range songs {
+		_, err := song_helpers.CreateSong(ctx, s.pgClient,
+			song_helpers.WithGroupName(song.groupName),
+			song_helpers.WithSongTitle(song.songTitle),
+			song_helpers.WithReleaseDate(song.releaseDate))
+		s.Require().NoError(err)
+	}
+	_, err := song_helpers.CreateSong(ctx, s.pgClient,
+		song_helpers.WithGroupName("Muse"),
+		song_helpers.WithSongTitle("Hysteria"),
+		song_helpers.WithReleaseDate("2009-09-07"))
+	s.Require().NoError(err)
+
+	var firstPage models.SongListResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter?sort=-releaseDate,songTitle&pageSize=3", nil, &firstPage)
+	s.Require().NoError(err)
+
+	s.Require().Len(firstPage.Items, 3)
+	s.Require().Equal("Hysteria", firstPage.Items[0].SongTitle)
+	s.Require().Equal("Uprising", firstPage.Items[1].SongTitle)
+	s.Require().Equal("505", firstPage.Items[2].SongTitle)
+
+	var secondPage models.SongListResponse
+	url := "/songs/filter?sort=-releaseDate,songTitle&pageSize=3&after=" + *firstPage.NextCursor
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, url, nil, &secondPage)
+	s.Require().NoError(err)
+
+	s.Require().Len(secondPage.Items, 3)
+	s.Require().Equal("Yellow", secondPage.Items[0].SongTitle)
+	s.Require().Equal("Song 2", secondPage.Items[1].SongTitle)
+	s.Require().Equal("Gruppa krovi", secondPage.Items[2].SongTitle)
+}
+
+func (s *SongSuite) TestGetFilteredSongsReleaseDateRange() {
+	ctx := context.Background()
+
+	releases := map[string]string{
+		"Smells Like Teen Spirit": "1991-09-10",
+		"Wonderwall":              "1995-10-30",
+		"Hey Ya!":                 "2003-08-25",
+	}
+	for title, releaseDate := range releases {
+		_, err := song_helpers.CreateSong(ctx, s.pgClient,
+			song_helpers.WithSongTitle(title),
+			song_helpers.WithReleaseDate(releaseDate))
+		s.Require().NoError(err)
+	}
+	_, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongTitle("Undated"))
+	s.Require().NoError(err)
+
+	var nineties models.SongListResponse
+	url := "/songs/filter?releasedFrom=1990-01-01&releasedTo=1999-12-31&sort=releaseDate"
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, url, nil, &nineties)
+	s.Require().NoError(err)
+
+	s.Require().Len(nineties.Items, 2)
+	s.Require().Equal("Smells Like Teen Spirit", nineties.Items[0].SongTitle)
+	s.Require().Equal(makeDate("1991-09-10"), nineties.Items[0].ReleaseDate)
+	s.Require().Equal("Wonderwall", nineties.Items[1].SongTitle)
+
+	var since2003 models.SongListResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter?releasedFrom=20030101", nil, &since2003)
+	s.Require().NoError(err)
+
+	s.Require().Len(since2003.Items, 1)
+	s.Require().Equal("Hey Ya!", since2003.Items[0].SongTitle)
+
+	// Query dates are strings, so Unix timestamps are not accepted there.
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodGet, "/songs/filter?releasedFrom=1041379200", nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusBadRequest), errResp.Status)
+}
 
-	s.Require().Equal("TestGroup", filteredSongs[0].GroupName)
-	s.Require().Equal("TestSong", filteredSongs[0].SongTitle)
+func (s *SongSuite) TestGetFilteredSongsInvalidReleaseDateRange() {
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodGet, "/songs/filter?releasedFrom=2000-01-01&releasedTo=1990-01-01", nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusBadRequest), errResp.Status)
+
+	errResp, err = makeJsonRequestWithErrorResp(s.httpHandler, http.MethodGet, "/songs/filter?releasedFrom=16.07.2006", nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusBadRequest), errResp.Status)
+}
+
+func (s *SongSuite) TestCreateSongWithEpochReleaseDate() {
+	req := []byte(`{"song": {"groupName": "Muse", "songTitle": "Starlight",
+		"releaseDate": 1157155200, "songText": "Far away", "link": "http://starlight.com"}}`)
+	var songCreateRes models.Song
+
+	_, err := makeJsonRequest(s.httpHandler, http.MethodPost, "/songs/create", json.RawMessage(req), &songCreateRes)
+	s.Require().NoError(err)
+
+	s.Require().Equal(makeDate("2006-09-02"), songCreateRes.ReleaseDate)
+}
+
+func (s *SongSuite) TestSongTimestamps() {
+	before := time.Now().Unix()
+
+	req := models.SongCreateRequest{
+		Song: &models.Song{
+			GroupName:   "Muse",
+			SongTitle:   "Plug In Baby",
+			ReleaseDate: makeDate("2001-03-05"),
+			SongText:    "I've exposed your lies",
+			Link:        "http://pluginbaby.com",
+		},
+	}
+	var created models.Song
+	_, err := makeJsonRequest(s.httpHandler, http.MethodPost, "/songs/create", req, &created)
+	s.Require().NoError(err)
+	s.Require().GreaterOrEqual(created.CreatedAt, before)
+	s.Require().Equal(created.CreatedAt, created.UpdatedAt)
+
+	_, err = s.pgClient.DB.ExecContext(context.Background(),
+		`UPDATE songs SET created_at = created_at - 60, updated_at = updated_at - 60 WHERE id = $1`, created.Id)
+	s.Require().NoError(err)
+
+	var updated models.Song
+	update := models.SongUpdateRequest{Song: &models.Song{SongTitle: "Plug In Baby (Live)"}}
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPatch, fmt.Sprintf("/songs/%d/update", created.Id), update, &updated)
+	s.Require().NoError(err)
+	s.Require().Equal(created.CreatedAt-60, updated.CreatedAt)
+	s.Require().GreaterOrEqual(updated.UpdatedAt, before)
+}
+
+func (s *SongSuite) TestCreateSongWithCompactReleaseDate() {
+	req := []byte(`{"song": {"groupName": "Muse", "songTitle": "Madness",
+		"releaseDate": "20220101", "songText": "I, I can't get these memories out of my mind", "link": "http://madness.com"}}`)
+	var songCreateRes models.Song
+
+	_, err := makeJsonRequest(s.httpHandler, http.MethodPost, "/songs/create", json.RawMessage(req), &songCreateRes)
+	s.Require().NoError(err)
+	s.Require().Equal(makeDate("2022-01-01"), songCreateRes.ReleaseDate)
+
+	var filtered models.SongListResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter?songTitle=Madness&releaseDate=20220101", nil, &filtered)
+	s.Require().NoError(err)
+	s.Require().Len(filtered.Items, 1)
+	s.Require().Equal(songCreateRes.Id, filtered.Items[0].Id)
+
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodGet, "/songs/filter?releaseDate=20221301", nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusBadRequest), errResp.Status)
+}
+
+func (s *SongSuite) TestCreateSongWithUnixReleaseDate() {
+	// Numbers are Unix seconds, even those that look like YYYYMMDD dates.
+	req := []byte(`{"song": {"groupName": "Muse", "songTitle": "Unintended",
+		"releaseDate": 31536000, "songText": "You could be my unintended", "link": "http://unintended.com"}}`)
+	var songCreateRes models.Song
+
+	_, err := makeJsonRequest(s.httpHandler, http.MethodPost, "/songs/create", json.RawMessage(req), &songCreateRes)
+	s.Require().NoError(err)
+	s.Require().Equal(makeDate("1971-01-01"), songCreateRes.ReleaseDate)
+
+	// 0 is an unknown date, left for the enrichment.
+	req = []byte(`{"song": {"groupName": "Muse", "songTitle": "Sunburn",
+		"releaseDate": 0, "songText": "She burns like the sun", "link": "http://sunburn.com"}}`)
+	songCreateRes = models.Song{}
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPost, "/songs/create", json.RawMessage(req), &songCreateRes)
+	s.Require().NoError(err)
+	s.Require().Nil(songCreateRes.ReleaseDate)
+	s.Require().Equal(makePointer(models.Pending), songCreateRes.EnrichmentStatus)
+}
+
+func (s *SongSuite) TestGetFilteredSongsInvalidSort() {
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodGet, "/songs/filter?sort=-releaseDate,password", nil)
+	s.Require().NoError(err)
+
+	s.Require().Equal(int64(http.StatusBadRequest), errResp.Status)
+	s.Require().Contains(*errResp.Detail, `invalid sort field "password"`)
+}
+
+func (s *SongSuite) TestGetFilteredSongsFuzzy() {
+	ctx := context.Background()
+
+	_, err := song_helpers.CreateSong(ctx, s.pgClient,
+		song_helpers.WithGroupName("Metallica"),
+		song_helpers.WithSongTitle("Nothing Else Matters"))
+	s.Require().NoError(err)
+
+	_, err = song_helpers.CreateSong(ctx, s.pgClient,
+		song_helpers.WithGroupName("Muse"),
+		song_helpers.WithSongTitle("Hysteria"))
+	s.Require().NoError(err)
+
+	var filteredSongs models.SongListResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter?groupName=Metalica", nil, &filteredSongs)
+	s.Require().NoError(err)
+	s.Require().Empty(filteredSongs.Items)
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter?groupName=Metalica&fuzzy=true", nil, &filteredSongs)
+	s.Require().NoError(err)
+
+	s.Require().Len(filteredSongs.Items, 1)
+	s.Require().Equal("Metallica", filteredSongs.Items[0].GroupName)
+	s.Require().NotNil(filteredSongs.Items[0].Score)
+	s.Require().Greater(*filteredSongs.Items[0].Score, 0.5)
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter?groupName=Mues&fuzzy=true", nil, &filteredSongs)
+	s.Require().NoError(err)
+
+	s.Require().Len(filteredSongs.Items, 1)
+	s.Require().Equal("Muse", filteredSongs.Items[0].GroupName)
+}
+
+func (s *SongSuite) TestGetFilteredSongsFuzzyOrderedByScore() {
+	ctx := context.Background()
+
+	_, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongTitle("Starlight Express"))
+	s.Require().NoError(err)
+
+	_, err = song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongTitle("Starlight"))
+	s.Require().NoError(err)
+
+	var filteredSongs models.SongListResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter?songTitle=Starlite&fuzzy=true", nil, &filteredSongs)
+	s.Require().NoError(err)
+
+	s.Require().Len(filteredSongs.Items, 2)
+	s.Require().Equal("Starlight", filteredSongs.Items[0].SongTitle)
+	s.Require().GreaterOrEqual(*filteredSongs.Items[0].Score, *filteredSongs.Items[1].Score)
+}
+
+func (s *SongSuite) TestCreateSongReusesNormalizedArtist() {
+	ctx := context.Background()
+
+	artistId, err := song_helpers.CreateArtist(ctx, s.pgClient, "Muse")
+	s.Require().NoError(err)
+
+	req := models.SongCreateRequest{
+		Song: &models.Song{
+			GroupName:   "  muse ",
+			SongTitle:   "Uprising",
+			ReleaseDate: makeDate("2009-09-07"),
+			SongText:    "Paranoia is in bloom",
+			Link:        "http://uprising.com",
+		},
+	}
+	var songCreateRes models.Song
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPost, "/songs/create", req, &songCreateRes)
+	s.Require().NoError(err)
+
+	s.Require().Equal(makePointer(artistId), songCreateRes.ArtistId)
+	s.Require().Equal("Muse", songCreateRes.GroupName)
+
+	var filteredSongs models.SongListResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter?groupName=MUSE", nil, &filteredSongs)
+	s.Require().NoError(err)
+
+	s.Require().Len(filteredSongs.Items, 1)
+	s.Require().Equal("Uprising", filteredSongs.Items[0].SongTitle)
+}
+
+func (s *SongSuite) TestSearchSongsRankedByRelevance() {
+	ctx := context.Background()
+
+	_, err := song_helpers.CreateSong(ctx, s.pgClient,
+		song_helpers.WithSongTitle("Starlight"),
+		song_helpers.WithSongText("Far away\nThis ship is taking me far away\n\nHold you in my arms"))
+	s.Require().NoError(err)
+
+	_, err = song_helpers.CreateSong(ctx, s.pgClient,
+		song_helpers.WithSongTitle("Hysteria"),
+		song_helpers.WithSongText("It's bugging me, grating me\n\nAnd twisting me around\nI'm taking what I want"))
+	s.Require().NoError(err)
+
+	_, err = song_helpers.CreateSong(ctx, s.pgClient,
+		song_helpers.WithSongTitle("Uprising"),
+		song_helpers.WithSongText("Paranoia is in bloom"))
+	s.Require().NoError(err)
+
+	var results []models.SongSearchResult
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/search?q=starlight%20taking&lang=en", nil, &results)
+	s.Require().NoError(err)
+
+	s.Require().Len(results, 1)
+	s.Require().Equal("Starlight", results[0].Song.SongTitle)
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/search?q=take", nil, &results)
+	s.Require().NoError(err)
+
+	s.Require().Len(results, 2)
+	s.Require().GreaterOrEqual(results[0].Rank, results[1].Rank)
+	s.Require().Contains(results[0].Snippet, "<mark>taking</mark>")
+}
+
+func (s *SongSuite) TestSearchSongsRussian() {
+	ctx := context.Background()
+
+	_, err := song_helpers.CreateSong(ctx, s.pgClient,
+		song_helpers.WithGroupName("Кино"),
+		song_helpers.WithSongTitle("Звезда по имени Солнце"),
+		song_helpers.WithSongText("Белый снег, серый лёд\nНа растрескавшейся земле"))
+	s.Require().NoError(err)
+
+	_, err = song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongTitle("TestSong"))
+	s.Require().NoError(err)
+
+	var results []models.SongSearchResult
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/search?q=звезды", nil, &results)
+	s.Require().NoError(err)
+
+	s.Require().Len(results, 1)
+	s.Require().Equal("Звезда по имени Солнце", results[0].Song.SongTitle)
+}
+
+func (s *SongSuite) TestSearchSongsEmptyQuery() {
+	_, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodGet, "/songs/search?q=%20", nil)
+	s.Require().NoError(err)
+}
+
+func (s *SongSuite) TestSearchSongsInvalidPage() {
+	for _, query := range []string{"page=0", "pageSize=-1", "after=abc"} {
+		errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodGet, "/songs/search?q=muse&"+query, nil)
+		s.Require().NoError(err)
+		s.Require().Equal(int64(http.StatusBadRequest), errResp.Status, query)
+	}
 }
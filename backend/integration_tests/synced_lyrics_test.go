@@ -0,0 +1,143 @@
+package integration_tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/salmon822/test_task/integration_tests/song_helpers"
+	"github.com/salmon822/test_task/models"
+)
+
+type SyncedLyricsSuite struct {
+	TestSuite
+}
+
+func (s *SyncedLyricsSuite) SetupSuite() {
+	s.TestSuite.SetupSuite()
+}
+
+const starlightLRC = `[ar:Muse]
+[ti:Starlight]
+[00:12.50]Far away
+[00:15.00]This ship is taking me far away
+[01:10.00]My life
+[01:15.25]You electrify my life
+`
+
+func (s *SyncedLyricsSuite) TestUploadAndDownloadLRC() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient,
+		song_helpers.WithGroupName("Muse"),
+		song_helpers.WithSongTitle("Starlight"))
+	s.Require().NoError(err)
+
+	url := fmt.Sprintf("/songs/%d/lyrics/lrc", createdSong)
+
+	code, body, err := makeTextRequest(s.httpHandler, http.MethodPut, url, starlightLRC)
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, code, body)
+
+	var uploadRes models.SyncedLyrics
+	s.Require().NoError(json.Unmarshal([]byte(body), &uploadRes))
+	s.Require().Equal(createdSong, uploadRes.SongId)
+	s.Require().Len(uploadRes.Lines, 4)
+	s.Require().Equal(models.SyncedLine{
+		Number:    4,
+		Time:      75.25,
+		Timestamp: "01:15.25",
+		Text:      "You electrify my life",
+	}, uploadRes.Lines[3])
+
+	code, body, err = makeTextRequest(s.httpHandler, http.MethodGet, url, "")
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, code, body)
+	s.Require().Equal(starlightLRC, body)
+}
+
+func (s *SyncedLyricsSuite) TestUploadLRCWithRepeatedLineAndOffset() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient)
+	s.Require().NoError(err)
+
+	lrc := "[offset:500]\n[00:10.00][00:30.00]Chorus\n[00:20.00]Verse\n"
+
+	code, body, err := makeTextRequest(s.httpHandler, http.MethodPut, fmt.Sprintf("/songs/%d/lyrics/lrc", createdSong), lrc)
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, code, body)
+
+	var uploadRes models.SyncedLyrics
+	s.Require().NoError(json.Unmarshal([]byte(body), &uploadRes))
+	s.Require().Len(uploadRes.Lines, 3)
+	s.Require().Equal("00:09.50", uploadRes.Lines[0].Timestamp)
+	s.Require().Equal([]string{"Chorus", "Verse", "Chorus"},
+		[]string{uploadRes.Lines[0].Text, uploadRes.Lines[1].Text, uploadRes.Lines[2].Text})
+	s.Require().Equal(29.5, uploadRes.Lines[2].Time)
+}
+
+func (s *SyncedLyricsSuite) TestUploadInvalidLRC() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient)
+	s.Require().NoError(err)
+
+	url := fmt.Sprintf("/songs/%d/lyrics/lrc", createdSong)
+
+	for _, lrc := range []string{
+		"[00:15.00]Second\n[00:12.50]First\n",
+		"[00:12.50]First\n[00:12.50]Again\n",
+		"[00:75.00]Too many seconds\n",
+		"[00:12.50]First\nNo timestamp\n",
+		"[ar:Muse]\n",
+	} {
+		code, body, err := makeTextRequest(s.httpHandler, http.MethodPut, url, lrc)
+		s.Require().NoError(err)
+		s.Require().Equal(http.StatusBadRequest, code, body)
+	}
+
+	code, _, err := makeTextRequest(s.httpHandler, http.MethodGet, url, "")
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusNotFound, code)
+}
+
+func (s *SyncedLyricsSuite) TestGetSyncedLyricsAt() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient)
+	s.Require().NoError(err)
+
+	code, body, err := makeTextRequest(s.httpHandler, http.MethodPut, fmt.Sprintf("/songs/%d/lyrics/lrc", createdSong), starlightLRC)
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, code, body)
+
+	var position models.SyncedLyricsPosition
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, fmt.Sprintf("/songs/%d/lyrics/at?t=73.5", createdSong), nil, &position)
+	s.Require().NoError(err)
+	s.Require().Equal(73.5, position.T)
+	s.Require().NotNil(position.Line)
+	s.Require().Equal("My life", position.Line.Text)
+	s.Require().NotNil(position.Next)
+	s.Require().Equal("You electrify my life", position.Next.Text)
+
+	position = models.SyncedLyricsPosition{}
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, fmt.Sprintf("/songs/%d/lyrics/at?t=5", createdSong), nil, &position)
+	s.Require().NoError(err)
+	s.Require().Nil(position.Line)
+	s.Require().NotNil(position.Next)
+	s.Require().Equal("Far away", position.Next.Text)
+
+	position = models.SyncedLyricsPosition{}
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, fmt.Sprintf("/songs/%d/lyrics/at?t=600", createdSong), nil, &position)
+	s.Require().NoError(err)
+	s.Require().NotNil(position.Line)
+	s.Require().Equal("You electrify my life", position.Line.Text)
+	s.Require().Nil(position.Next)
+
+	errResp, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodGet, fmt.Sprintf("/songs/%d/lyrics/at?t=-1", createdSong), nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(http.StatusBadRequest), errResp.Status)
+}
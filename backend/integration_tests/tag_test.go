@@ -0,0 +1,136 @@
+package integration_tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/salmon822/test_task/integration_tests/song_helpers"
+	"github.com/salmon822/test_task/models"
+)
+
+type TagSuite struct {
+	TestSuite
+}
+
+func (s *TagSuite) SetupSuite() {
+	s.TestSuite.SetupSuite()
+}
+
+func (s *TagSuite) TestAddSongTagsSuccess() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongTitle("TestSong"))
+	s.Require().NoError(err)
+
+	req := models.SongTagsRequest{
+		Tags: []models.Tag{
+			{Name: "Rock", Kind: makePointer(models.TagKindGenre)},
+			{Name: " live  recording "},
+		},
+	}
+	var tagsRes []models.Tag
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPost, fmt.Sprintf("/songs/%d/tags", createdSong), req, &tagsRes)
+	s.Require().NoError(err)
+
+	s.Require().Len(tagsRes, 2)
+	s.Require().Equal("Rock", tagsRes[0].Name)
+	s.Require().Equal(makePointer(models.TagKindGenre), tagsRes[0].Kind)
+	s.Require().Equal("live recording", tagsRes[1].Name)
+	s.Require().Equal(makePointer(models.TagKindTag), tagsRes[1].Kind)
+}
+
+func (s *TagSuite) TestReplaceSongTagsSuccess() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongTitle("TestSong"))
+	s.Require().NoError(err)
+	_, err = song_helpers.AddSongTag(ctx, s.pgClient, createdSong, "old", "tag")
+	s.Require().NoError(err)
+
+	req := models.SongTagsRequest{
+		Tags: []models.Tag{{Name: "new"}},
+	}
+	var tagsRes []models.Tag
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodPut, fmt.Sprintf("/songs/%d/tags", createdSong), req, &tagsRes)
+	s.Require().NoError(err)
+
+	s.Require().Len(tagsRes, 1)
+	s.Require().Equal("new", tagsRes[0].Name)
+}
+
+func (s *TagSuite) TestRemoveSongTagSuccess() {
+	ctx := context.Background()
+
+	createdSong, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongTitle("TestSong"))
+	s.Require().NoError(err)
+	tagId, err := song_helpers.AddSongTag(ctx, s.pgClient, createdSong, "chill", "tag")
+	s.Require().NoError(err)
+
+	_, err = makeJsonRequest(s.httpHandler, http.MethodDelete, fmt.Sprintf("/songs/%d/tags/%d", createdSong, tagId), nil, nil)
+	s.Require().NoError(err)
+
+	var tagsRes []models.Tag
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, fmt.Sprintf("/songs/%d/tags", createdSong), nil, &tagsRes)
+	s.Require().NoError(err)
+
+	s.Require().Empty(tagsRes)
+}
+
+func (s *TagSuite) TestFilterSongsByTags() {
+	ctx := context.Background()
+
+	both, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongTitle("Both"))
+	s.Require().NoError(err)
+	_, err = song_helpers.AddSongTag(ctx, s.pgClient, both, "chill", "tag")
+	s.Require().NoError(err)
+	_, err = song_helpers.AddSongTag(ctx, s.pgClient, both, "summer", "tag")
+	s.Require().NoError(err)
+
+	onlyChill, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongTitle("OnlyChill"))
+	s.Require().NoError(err)
+	_, err = song_helpers.AddSongTag(ctx, s.pgClient, onlyChill, "Chill", "tag")
+	s.Require().NoError(err)
+
+	_, err = song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongTitle("Untagged"))
+	s.Require().NoError(err)
+
+	var anySongs models.SongListResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter?tags=chill,summer&tagsMatch=any", nil, &anySongs)
+	s.Require().NoError(err)
+	s.Require().Len(anySongs.Items, 2)
+
+	var allSongs models.SongListResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter?tags=chill,summer&tagsMatch=all", nil, &allSongs)
+	s.Require().NoError(err)
+	s.Require().Len(allSongs.Items, 1)
+	s.Require().Equal("Both", allSongs.Items[0].SongTitle)
+}
+
+func (s *TagSuite) TestFilterSongsByGenre() {
+	ctx := context.Background()
+
+	rock, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongTitle("RockSong"))
+	s.Require().NoError(err)
+	_, err = song_helpers.AddSongTag(ctx, s.pgClient, rock, "Rock", "genre")
+	s.Require().NoError(err)
+
+	taggedRock, err := song_helpers.CreateSong(ctx, s.pgClient, song_helpers.WithSongTitle("TaggedRock"))
+	s.Require().NoError(err)
+	_, err = song_helpers.AddSongTag(ctx, s.pgClient, taggedRock, "Rock", "tag")
+	s.Require().NoError(err)
+
+	var songs models.SongListResponse
+	_, err = makeJsonRequest(s.httpHandler, http.MethodGet, "/songs/filter?genre=rock", nil, &songs)
+	s.Require().NoError(err)
+
+	s.Require().Len(songs.Items, 1)
+	s.Require().Equal("RockSong", songs.Items[0].SongTitle)
+}
+
+func (s *TagSuite) TestFilterSongsInvalidTagsMatch() {
+	_, err := makeJsonRequestWithErrorResp(s.httpHandler, http.MethodGet, "/songs/filter?tags=chill&tagsMatch=some", nil)
+	s.Require().NoError(err)
+}
@@ -3,12 +3,14 @@ package integration_tests
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 
 	"github.com/salmon822/test_task/internal/config"
 	"github.com/salmon822/test_task/internal/db"
 	"github.com/salmon822/test_task/internal/handler"
+	"github.com/salmon822/test_task/internal/musicinfo"
 	"github.com/salmon822/test_task/internal/pkg/logger"
 	"github.com/salmon822/test_task/internal/repository"
 	"github.com/salmon822/test_task/internal/server"
@@ -23,8 +25,11 @@ type TestSuite struct {
 	cfg    *config.Config
 	logger logger.Logger
 
+	services    service.Service
 	httpHandler http.Handler
 	srv         *server.Server
+
+	musicInfoServer *httptest.Server
 }
 
 func (s *TestSuite) SetupSuite() {
@@ -48,10 +53,15 @@ func (s *TestSuite) SetupSuite() {
 	repo, err := repository.NewRepository(s.cfg, s.pgClient.DB, s.logger)
 	s.Require().NoError(err, "Failed to initialize repository")
 
-	services, err := service.NewService(context.Background(), repo, s.logger)
+	s.musicInfoServer = httptest.NewServer(newFakeMusicInfoHandler())
+	s.cfg.MusicInfo.BaseURL = s.musicInfoServer.URL
+
+	musicInfo := musicinfo.NewClient(s.cfg.MusicInfo, s.logger)
+
+	s.services, err = service.NewService(context.Background(), s.cfg, repo, musicInfo, s.logger)
 	s.Require().NoError(err, "Failed to initialize services")
 
-	h := handler.NewHandler(services.Songs, s.cfg.Handler, s.logger)
+	h := handler.NewHandler(s.services.Songs, s.services.Artists, s.services.Albums, s.services.Tags, s.services.SongLyrics, s.services.SyncedLyrics, s.cfg.Handler, s.logger)
 	s.httpHandler = h.Init()
 
 	s.srv = server.NewServer(s.cfg.Server, s.httpHandler)
@@ -63,6 +73,10 @@ func (s *TestSuite) TearDownSuite() {
 		s.Require().NoError(err)
 	}
 
+	if s.musicInfoServer != nil {
+		s.musicInfoServer.Close()
+	}
+
 	if s.pgClient != nil {
 		s.pgClient.DB.Close()
 	}
@@ -73,7 +87,14 @@ func (s *TestSuite) TearDownTest() {
 
 	query := `
 		DELETE FROM songs;
+		DELETE FROM song_revisions;
 		ALTER SEQUENCE songs_id_seq RESTART WITH 1;
+		DELETE FROM tags;
+		ALTER SEQUENCE tags_id_seq RESTART WITH 1;
+		DELETE FROM albums;
+		ALTER SEQUENCE albums_id_seq RESTART WITH 1;
+		DELETE FROM artists;
+		ALTER SEQUENCE artists_id_seq RESTART WITH 1;
 	`
 	_, err := s.pgClient.DB.ExecContext(ctx, query)
 	s.Require().NoError(err)
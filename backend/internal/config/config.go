@@ -13,6 +13,9 @@ type (
 		Postgres           *PostgresConfig
 		Handler            *HandlerConfig
 		PostgresTestConfig *PostgresTestConfig
+		MusicInfo          *MusicInfoConfig
+		EnrichmentWorker   *EnrichmentWorkerConfig
+		TrashPurgeWorker   *TrashPurgeWorkerConfig
 	}
 	PostgresConfig struct {
 		Host     string
@@ -36,13 +39,41 @@ type (
 	}
 	HandlerConfig struct {
 		RequestTimeout time.Duration
+		ImportTimeout  time.Duration
+		ExportTimeout  time.Duration
 		QueueSize      int
 	}
+	MusicInfoConfig struct {
+		BaseURL    string
+		Timeout    time.Duration
+		RetryCount int
+		RetryDelay time.Duration
+	}
+	EnrichmentWorkerConfig struct {
+		Interval    time.Duration
+		BatchSize   int64
+		MaxAttempts int64
+		BaseBackoff time.Duration
+		MaxBackoff  time.Duration
+	}
+	TrashPurgeWorkerConfig struct {
+		Interval  time.Duration
+		Retention time.Duration
+		BatchSize int64
+	}
+)
+
+// Defaults of the settings the service cannot run without.
+const (
+	defaultEnrichmentWorkerInterval = 30 * time.Second
+	defaultTrashPurgeWorkerInterval = time.Hour
 )
 
 func Init(configPath string) (*Config, error) {
 	jsonCfg := viper.New()
 	jsonCfg.SetConfigFile(configPath)
+	jsonCfg.SetDefault("enrichmentWorker.interval", defaultEnrichmentWorkerInterval)
+	jsonCfg.SetDefault("trashPurgeWorker.interval", defaultTrashPurgeWorkerInterval)
 
 	if err := jsonCfg.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("config/Init/jsonCfg.ReadInConfig: %w", err)
@@ -54,7 +85,7 @@ func Init(configPath string) (*Config, error) {
 	if err := envCfg.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("config/Init/envCfg.ReadInConfig: %w", err)
 	}
-	return &Config{
+	cfg := &Config{
 		Server: &ServerConfig{
 			Port:           jsonCfg.GetInt("server.port"),
 			ReadTimeout:    jsonCfg.GetDuration("server.readTimeout"),
@@ -77,9 +108,46 @@ func Init(configPath string) (*Config, error) {
 		},
 		Handler: &HandlerConfig{
 			RequestTimeout: jsonCfg.GetDuration("handler.requestTimeout"),
+			ImportTimeout:  jsonCfg.GetDuration("handler.importTimeout"),
+			ExportTimeout:  jsonCfg.GetDuration("handler.exportTimeout"),
 			QueueSize:      jsonCfg.GetInt("handler.queueSize"),
 		},
-	}, nil
+		MusicInfo: &MusicInfoConfig{
+			BaseURL:    jsonCfg.GetString("musicInfo.baseUrl"),
+			Timeout:    jsonCfg.GetDuration("musicInfo.timeout"),
+			RetryCount: jsonCfg.GetInt("musicInfo.retryCount"),
+			RetryDelay: jsonCfg.GetDuration("musicInfo.retryDelay"),
+		},
+		EnrichmentWorker: &EnrichmentWorkerConfig{
+			Interval:    jsonCfg.GetDuration("enrichmentWorker.interval"),
+			BatchSize:   jsonCfg.GetInt64("enrichmentWorker.batchSize"),
+			MaxAttempts: jsonCfg.GetInt64("enrichmentWorker.maxAttempts"),
+			BaseBackoff: jsonCfg.GetDuration("enrichmentWorker.baseBackoff"),
+			MaxBackoff:  jsonCfg.GetDuration("enrichmentWorker.maxBackoff"),
+		},
+		TrashPurgeWorker: &TrashPurgeWorkerConfig{
+			Interval:  jsonCfg.GetDuration("trashPurgeWorker.interval"),
+			Retention: jsonCfg.GetDuration("trashPurgeWorker.retention"),
+			BatchSize: jsonCfg.GetInt64("trashPurgeWorker.batchSize"),
+		},
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("config/Init: %w", err)
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) validate() error {
+	if c.EnrichmentWorker.Interval <= 0 {
+		return fmt.Errorf("enrichmentWorker.interval must be positive, got %s", c.EnrichmentWorker.Interval)
+	}
+	if c.TrashPurgeWorker.Interval <= 0 {
+		return fmt.Errorf("trashPurgeWorker.interval must be positive, got %s", c.TrashPurgeWorker.Interval)
+	}
+
+	return nil
 }
 
 func (p *PostgresConfig) PgSource() string {
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/salmon822/test_task/models"
+)
+
+type Album struct {
+	ID          int64
+	ArtistID    int64
+	GroupName   string
+	Title       string
+	ReleaseDate time.Time
+	CoverLink   string
+	CreatedAt   int64
+	UpdatedAt   int64
+}
+
+type AlbumTracks struct {
+	Album  Album
+	Tracks []*Song
+}
+
+type TrackPosition struct {
+	SongID      int64
+	DiscNumber  int64
+	TrackNumber int64
+}
+
+func AlbumDomain2Models(a *Album) *models.Album {
+	if a == nil {
+		return nil
+	}
+	album := &models.Album{
+		Id:          a.ID,
+		GroupName:   a.GroupName,
+		Title:       a.Title,
+		ReleaseDate: DateDomain2Models(a.ReleaseDate),
+		CoverLink:   a.CoverLink,
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+	}
+	if a.ArtistID != 0 {
+		album.ArtistId = &a.ArtistID
+	}
+
+	return album
+}
+
+func AlbumModels2Domain(a *models.Album) *Album {
+	if a == nil {
+		return nil
+	}
+	album := &Album{
+		ID:          a.Id,
+		GroupName:   a.GroupName,
+		Title:       a.Title,
+		ReleaseDate: DateModels2Domain(a.ReleaseDate),
+		CoverLink:   a.CoverLink,
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+	}
+
+	return album
+}
+
+func AlbumTracksDomain2Models(a *AlbumTracks) *models.AlbumTracksResponse {
+	if a == nil {
+		return nil
+	}
+	return &models.AlbumTracksResponse{
+		Album:  AlbumDomain2Models(&a.Album),
+		Tracks: MapSlice(a.Tracks, SongDomain2Models),
+	}
+}
+
+func TrackPositionModels2Domain(p models.TrackPosition) *TrackPosition {
+	position := &TrackPosition{
+		SongID:      p.SongId,
+		DiscNumber:  1,
+		TrackNumber: p.TrackNumber,
+	}
+	if p.DiscNumber != nil {
+		position.DiscNumber = *p.DiscNumber
+	}
+
+	return position
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"github.com/salmon822/test_task/models"
+)
+
+type Artist struct {
+	ID        int64
+	Name      string
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+type ArtistFilters struct {
+	Name *string
+}
+
+func ArtistDomain2Models(a *Artist) *models.Artist {
+	if a == nil {
+		return nil
+	}
+	artist := &models.Artist{
+		Id:        a.ID,
+		Name:      a.Name,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+
+	return artist
+}
+
+func ArtistModels2Domain(a *models.Artist) *Artist {
+	if a == nil {
+		return nil
+	}
+	artist := &Artist{
+		ID:        a.Id,
+		Name:      a.Name,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+
+	return artist
+}
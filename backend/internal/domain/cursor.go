@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// SongCursor is the position of a song in a listing: the values of the sort
+// keys of the listing followed by the song ID.
+type SongCursor struct {
+	Keys []any `json:"k,omitempty"`
+	ID   int64 `json:"id"`
+}
+
+// EncodeSongCursor turns the cursor into the opaque token handed to clients.
+func EncodeSongCursor(c *SongCursor) *string {
+	if c == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	token := base64.RawURLEncoding.EncodeToString(data)
+
+	return &token
+}
+
+// DecodeSongCursor parses a token produced by EncodeSongCursor.
+func DecodeSongCursor(token string) (*SongCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var cursor SongCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	if cursor.ID <= 0 {
+		return nil, fmt.Errorf("invalid cursor: missing song id")
+	}
+
+	return &cursor, nil
+}
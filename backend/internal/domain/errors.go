@@ -0,0 +1,26 @@
+package domain
+
+import "errors"
+
+// Errors are marked with one of these sentinels, wrapped with %w anywhere in
+// the chain, to tell what went wrong. The handlers answer each of them with
+// its own status code.
+var (
+	// ErrInvalidRequest marks malformed requests, e.g. a path ID that is not a
+	// number or a body that is not JSON.
+	ErrInvalidRequest = errors.New("invalid request")
+	// ErrValidation marks well-formed requests with values that are not
+	// accepted.
+	ErrValidation = errors.New("validation failed")
+	// ErrNotFound marks requests for a resource that does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict marks writes that clash with the stored data, e.g. a
+	// duplicate or a resource that is still in use.
+	ErrConflict = errors.New("conflict")
+	// ErrPreconditionFailed marks writes refused by a precondition of the
+	// request.
+	ErrPreconditionFailed = errors.New("precondition failed")
+	// ErrUnsupportedMediaType marks bodies in a format the endpoint does not
+	// read.
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+)
@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"time"
+
+	"github.com/salmon822/test_task/models"
+)
+
 func MapSlice[T1, T2 any](x []T1, converter func(T1) T2) []T2 {
 	res := make([]T2, len(x))
 	for i := range x {
@@ -7,3 +13,19 @@ func MapSlice[T1, T2 any](x []T1, converter func(T1) T2) []T2 {
 	}
 	return res
 }
+
+// DateDomain2Models converts a date, the zero time standing for an unknown
+// date, into its API form.
+func DateDomain2Models(t time.Time) *models.Date {
+	if t.IsZero() {
+		return nil
+	}
+	return &models.Date{Time: t}
+}
+
+func DateModels2Domain(d *models.Date) time.Time {
+	if d == nil {
+		return time.Time{}
+	}
+	return d.Time
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"github.com/salmon822/test_task/models"
+)
+
+const (
+	LyricsUnitLine   = "line"
+	LyricsUnitStanza = "stanza"
+)
+
+// Stanza is a block of lyrics lines. Section is the normalized kind of the
+// section marker preceding it, e.g. "chorus" for "[Chorus x2]", and Label is
+// the marker as written.
+type Stanza struct {
+	Number  int64
+	Section string
+	Label   string
+	Lines   []string
+}
+
+// SongLyrics is a version of the song text in another language, Language is
+// a canonical BCP 47 tag.
+type SongLyrics struct {
+	SongID    int64
+	Language  string
+	SongText  string
+	Stanzas   []*Stanza
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+// LyricsPagination selects a page of the song lyrics. Languages lists the
+// preferred languages, most preferred first, the original lyrics are paged
+// when none of them is available.
+type LyricsPagination struct {
+	Languages []string
+	Unit      string
+	Page      int64
+	PageSize  int64
+}
+
+func StanzaDomain2Models(s *Stanza) models.Stanza {
+	stanza := models.Stanza{
+		Number: s.Number,
+		Lines:  s.Lines,
+	}
+	if s.Section != "" {
+		stanza.Section = &s.Section
+	}
+	if s.Label != "" {
+		stanza.Label = &s.Label
+	}
+
+	return stanza
+}
+
+func SongWithVersesDomain2Models(s *SongWithVerses) *models.SongWithVerses {
+	if s == nil {
+		return nil
+	}
+	res := &models.SongWithVerses{
+		Song:         SongDomain2Models(&s.Song),
+		Unit:         models.SongWithVersesUnit(s.Unit),
+		TotalVerses:  s.TotalVerses,
+		TotalStanzas: s.TotalStanzas,
+		Page:         s.Page,
+		PageSize:     s.PageSize,
+	}
+	if s.Language != "" {
+		res.Language = &s.Language
+	}
+	switch s.Unit {
+	case LyricsUnitStanza:
+		stanzas := MapSlice(s.Stanzas, StanzaDomain2Models)
+		res.Stanzas = &stanzas
+	default:
+		res.Verses = &s.Verses
+	}
+
+	return res
+}
+
+func SongLyricsDomain2Models(s *SongLyrics) *models.SongLyrics {
+	if s == nil {
+		return nil
+	}
+	return &models.SongLyrics{
+		SongId:    s.SongID,
+		Language:  s.Language,
+		SongText:  s.SongText,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
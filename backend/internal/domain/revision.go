@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/salmon822/test_task/models"
+)
+
+const (
+	SongRevisionCreate  = "create"
+	SongRevisionUpdate  = "update"
+	SongRevisionDelete  = "delete"
+	SongRevisionRestore = "restore"
+)
+
+// SongRevision is the state of a song after a change. The song of a delete
+// revision is its state before it was deleted.
+type SongRevision struct {
+	SongID    int64
+	Revision  int64
+	Action    string
+	Song      Song
+	Changes   []SongFieldChange
+	CreatedAt int64
+}
+
+// SongFieldChange is the change of a song field between two revisions, a nil
+// value stands for an empty field.
+type SongFieldChange struct {
+	Field string
+	Old   any
+	New   any
+}
+
+// songRevisionFields lists the song fields compared between revisions.
+var songRevisionFields = []struct {
+	name  string
+	value func(s *Song) any
+}{
+	{"groupName", func(s *Song) any { return emptyToNil(s.GroupName) }},
+	{"songTitle", func(s *Song) any { return emptyToNil(s.SongTitle) }},
+	{"releaseDate", func(s *Song) any {
+		if s.ReleaseDate.IsZero() {
+			return nil
+		}
+		return s.ReleaseDate.Format(time.DateOnly)
+	}},
+	{"songText", func(s *Song) any { return emptyToNil(s.SongText) }},
+	{"link", func(s *Song) any { return emptyToNil(s.Link) }},
+	{"albumId", func(s *Song) any { return emptyToNil(s.AlbumID) }},
+	{"discNumber", func(s *Song) any { return emptyToNil(s.DiscNumber) }},
+	{"trackNumber", func(s *Song) any { return emptyToNil(s.TrackNumber) }},
+}
+
+func emptyToNil[T comparable](value T) any {
+	var zero T
+	if value == zero {
+		return nil
+	}
+	return value
+}
+
+// SongChanges lists the fields that differ between two states of a song, a
+// nil state standing for a song that does not exist.
+func SongChanges(before, after *Song) []SongFieldChange {
+	changes := []SongFieldChange{}
+	for _, field := range songRevisionFields {
+		var old, new any
+		if before != nil {
+			old = field.value(before)
+		}
+		if after != nil {
+			new = field.value(after)
+		}
+		if old != new {
+			changes = append(changes, SongFieldChange{Field: field.name, Old: old, New: new})
+		}
+	}
+
+	return changes
+}
+
+func SongRevisionDomain2Models(r *SongRevision) *models.SongRevision {
+	if r == nil {
+		return nil
+	}
+	changes := make([]models.SongFieldChange, 0, len(r.Changes))
+	for _, change := range r.Changes {
+		changes = append(changes, models.SongFieldChange{
+			Field: change.Field,
+			Old:   change.Old,
+			New:   change.New,
+		})
+	}
+	return &models.SongRevision{
+		Revision:  r.Revision,
+		Action:    models.SongRevisionAction(r.Action),
+		Song:      SongDomain2Models(&r.Song),
+		Changes:   changes,
+		CreatedAt: r.CreatedAt,
+	}
+}
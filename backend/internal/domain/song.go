@@ -1,32 +1,186 @@
 package domain
 
 import (
+	"fmt"
+	"slices"
+	"time"
+
 	"github.com/salmon822/test_task/models"
 )
 
+const (
+	SongEnrichmentPending  = "pending"
+	SongEnrichmentEnriched = "enriched"
+	SongEnrichmentFailed   = "failed"
+)
+
+const (
+	TagsMatchAny = "any"
+	TagsMatchAll = "all"
+)
+
+const (
+	SearchLanguageEnglish = "english"
+	SearchLanguageRussian = "russian"
+)
+
 type Song struct {
 	ID          int64
+	ArtistID    int64
 	GroupName   string
 	SongTitle   string
-	ReleaseDate int64
+	ReleaseDate time.Time
 	SongText    string
+	Stanzas     []*Stanza
 	Link        string
 	CreatedAt   int64
 	UpdatedAt   int64
+	// DeletedAt is the time the song was moved to the trash, zero when it is
+	// not there.
+	DeletedAt int64
+	// Version is bumped by every write to the song, it is sent as the ETag.
+	Version int64
+
+	EnrichmentStatus    string
+	EnrichmentAttempts  int64
+	EnrichmentLastError string
+
+	AlbumID     int64
+	DiscNumber  int64
+	TrackNumber int64
+
+	Score float64
+}
+
+// NeedsEnrichment reports whether any of the fields provided by the music info
+// service are still missing.
+func (s *Song) NeedsEnrichment() bool {
+	return s.ReleaseDate.IsZero() || s.SongText == "" || s.Link == ""
+}
+
+// ErrSongVersionMismatch is returned for a write conditioned on versions of
+// the song other than the current one.
+var ErrSongVersionMismatch = fmt.Errorf("%w: song was changed since it was read", ErrPreconditionFailed)
+
+// SongVersionMatch is the condition of a write on the version of the song,
+// as sent in If-Match. Any matches every version, a nil condition as well.
+type SongVersionMatch struct {
+	Any      bool
+	Versions []int64
 }
 
+func (m *SongVersionMatch) Matches(version int64) bool {
+	return m == nil || m.Any || slices.Contains(m.Versions, version)
+}
+
+// SongWithVerses is a page of the song lyrics. Unit tells whether the page is
+// made of lines (Verses) or of stanzas (Stanzas). Language is the language of
+// the translation the page comes from, empty for the original lyrics.
 type SongWithVerses struct {
 	Song
-	TotalVerses int64
-	Page        int64
-	PageSize    int64
-	Verses      []string
+	Language     string
+	Unit         string
+	TotalVerses  int64
+	TotalStanzas int64
+	Page         int64
+	PageSize     int64
+	Verses       []string
+	Stanzas      []*Stanza
 }
 
 type SongFilters struct {
 	GroupName   *string
+	ArtistID    *int64
+	AlbumID     *int64
 	SongTitle   *string
-	ReleaseDate *int64
+	ReleaseDate *time.Time
+	// ReleasedFrom and ReleasedTo bound the release date, both inclusive.
+	ReleasedFrom *time.Time
+	ReleasedTo   *time.Time
+	Tags         []string
+	TagsMatch    string
+	Genre        *string
+	Fuzzy        bool
+	// IncludeDeleted lists the songs in the trash along with the others.
+	IncludeDeleted bool
+}
+
+const (
+	SongSortID          = "id"
+	SongSortGroupName   = "groupName"
+	SongSortSongTitle   = "songTitle"
+	SongSortReleaseDate = "releaseDate"
+	SongSortCreatedAt   = "createdAt"
+	SongSortUpdatedAt   = "updatedAt"
+	SongSortScore       = "score"
+)
+
+// SongSortFields lists the fields a song listing can be sorted by.
+var SongSortFields = []string{
+	SongSortID,
+	SongSortGroupName,
+	SongSortSongTitle,
+	SongSortReleaseDate,
+	SongSortCreatedAt,
+	SongSortUpdatedAt,
+	SongSortScore,
+}
+
+// SongFields lists the fields a song can be selected by in a sparse fieldset,
+// as named in the API.
+var SongFields = []string{
+	"id",
+	"artistId",
+	SongFieldGroupName,
+	SongFieldSongTitle,
+	SongFieldReleaseDate,
+	SongFieldSongText,
+	SongFieldLink,
+	"createdAt",
+	"updatedAt",
+	"deletedAt",
+	"enrichmentStatus",
+	SongFieldAlbumID,
+	SongFieldDiscNumber,
+	SongFieldTrackNumber,
+}
+
+type SongSort struct {
+	Field string
+	Desc  bool
+}
+
+// SongPagination selects a page of a song listing. Page is used for offset
+// paging and is ignored when one of the cursors is set.
+type SongPagination struct {
+	Sort     []SongSort
+	Page     int64
+	PageSize int64
+	After    *SongCursor
+	Before   *SongCursor
+}
+
+// SongList is a page of a song listing. Page is only known for offset paging
+// and is zero for pages selected by a cursor.
+type SongList struct {
+	Items      []*Song
+	Page       int64
+	PageSize   int64
+	TotalItems int64
+	TotalPages int64
+	NextCursor *SongCursor
+	PrevCursor *SongCursor
+}
+
+type SongSearch struct {
+	Query    string
+	Language string
+}
+
+type SongSearchResult struct {
+	Song    Song
+	Rank    float64
+	Snippet string
 }
 
 func SongDomain2Models(s *Song) *models.Song {
@@ -37,12 +191,34 @@ func SongDomain2Models(s *Song) *models.Song {
 		Id:          s.ID,
 		GroupName:   s.GroupName,
 		SongTitle:   s.SongTitle,
-		ReleaseDate: s.ReleaseDate,
+		ReleaseDate: DateDomain2Models(s.ReleaseDate),
 		SongText:    s.SongText,
 		Link:        s.Link,
 		CreatedAt:   s.CreatedAt,
 		UpdatedAt:   s.UpdatedAt,
 	}
+	if s.ArtistID != 0 {
+		song.ArtistId = &s.ArtistID
+	}
+	if s.AlbumID != 0 {
+		song.AlbumId = &s.AlbumID
+	}
+	if s.DiscNumber != 0 {
+		song.DiscNumber = &s.DiscNumber
+	}
+	if s.TrackNumber != 0 {
+		song.TrackNumber = &s.TrackNumber
+	}
+	if s.EnrichmentStatus != "" {
+		status := models.SongEnrichmentStatus(s.EnrichmentStatus)
+		song.EnrichmentStatus = &status
+	}
+	if s.Score != 0 {
+		song.Score = &s.Score
+	}
+	if s.DeletedAt != 0 {
+		song.DeletedAt = &s.DeletedAt
+	}
 
 	return song
 }
@@ -55,12 +231,51 @@ func SongModels2Domain(s *models.Song) *Song {
 		ID:          s.Id,
 		GroupName:   s.GroupName,
 		SongTitle:   s.SongTitle,
-		ReleaseDate: s.ReleaseDate,
+		ReleaseDate: DateModels2Domain(s.ReleaseDate),
 		SongText:    s.SongText,
 		Link:        s.Link,
 		CreatedAt:   s.CreatedAt,
 		UpdatedAt:   s.UpdatedAt,
 	}
+	if s.AlbumId != nil {
+		song.AlbumID = *s.AlbumId
+	}
+	if s.DiscNumber != nil {
+		song.DiscNumber = *s.DiscNumber
+	}
+	if s.TrackNumber != nil {
+		song.TrackNumber = *s.TrackNumber
+	}
 
 	return song
 }
+
+func SongSearchResultDomain2Models(s *SongSearchResult) *models.SongSearchResult {
+	if s == nil {
+		return nil
+	}
+	return &models.SongSearchResult{
+		Song:    SongDomain2Models(&s.Song),
+		Rank:    s.Rank,
+		Snippet: s.Snippet,
+	}
+}
+
+func SongListDomain2Models(s *SongList) *models.SongListResponse {
+	if s == nil {
+		return nil
+	}
+	list := &models.SongListResponse{
+		Items:      MapSlice(s.Items, SongDomain2Models),
+		PageSize:   s.PageSize,
+		TotalItems: s.TotalItems,
+		TotalPages: s.TotalPages,
+		NextCursor: EncodeSongCursor(s.NextCursor),
+		PrevCursor: EncodeSongCursor(s.PrevCursor),
+	}
+	if s.Page != 0 {
+		list.Page = &s.Page
+	}
+
+	return list
+}
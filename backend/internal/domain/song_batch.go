@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"github.com/salmon822/test_task/models"
+)
+
+const (
+	// SongBatchAtomic applies every operation or none of them.
+	SongBatchAtomic = "atomic"
+	// SongBatchIndependent applies every operation on its own, a failing one
+	// does not affect the others.
+	SongBatchIndependent = "independent"
+)
+
+const (
+	SongBatchCreate = "create"
+	SongBatchUpdate = "update"
+	SongBatchDelete = "delete"
+)
+
+const (
+	SongBatchSucceeded = "succeeded"
+	SongBatchFailed    = "failed"
+	// SongBatchSkipped marks operations left out, or rolled back, because an
+	// atomic batch failed.
+	SongBatchSkipped = "skipped"
+)
+
+// SongBatchOperation is an operation of a batch. Song holds the song to create
+// or the fields to update, Match the condition on the version of the song to
+// update or delete. Err is set when the operation is not valid and must not be
+// applied.
+type SongBatchOperation struct {
+	Op    string
+	ID    int64
+	Song  *Song
+	Match *SongVersionMatch
+	Err   error
+}
+
+// SongBatchResult is the outcome of an operation of a batch. Song is the song
+// as saved by a create or an update.
+type SongBatchResult struct {
+	Op     string
+	Status string
+	SongID int64
+	Song   *Song
+	Err    error
+}
+
+type SongBatchReport struct {
+	Mode      string
+	Committed bool
+	Succeeded int64
+	Failed    int64
+	Results   []*SongBatchResult
+}
+
+func SongBatchOperationModels2Domain(o *models.SongBatchOperation) *SongBatchOperation {
+	op := &SongBatchOperation{
+		Op:   string(o.Op),
+		Song: SongModels2Domain(o.Song),
+	}
+	if o.Id != nil {
+		op.ID = *o.Id
+	}
+	if o.Version != nil {
+		op.Match = &SongVersionMatch{Versions: []int64{*o.Version}}
+	}
+	return op
+}
+
+// SongBatchReportDomain2Models converts the report, problem turns the errors
+// of the failed operations into problem details.
+func SongBatchReportDomain2Models(r *SongBatchReport, problem func(error) models.ErrorResponse) *models.SongBatchResponse {
+	if r == nil {
+		return nil
+	}
+	results := make([]models.SongBatchResult, 0, len(r.Results))
+	for i, result := range r.Results {
+		res := models.SongBatchResult{
+			Index:  int64(i),
+			Op:     models.SongBatchOperationOp(result.Op),
+			Status: models.SongBatchResultStatus(result.Status),
+			Song:   SongDomain2Models(result.Song),
+		}
+		if result.SongID != 0 {
+			res.Id = &result.SongID
+		}
+		if result.Err != nil {
+			errResp := problem(result.Err)
+			res.Error = &errResp
+		}
+		results = append(results, res)
+	}
+	return &models.SongBatchResponse{
+		Mode:      models.SongBatchMode(r.Mode),
+		Committed: r.Committed,
+		Succeeded: r.Succeeded,
+		Failed:    r.Failed,
+		Results:   results,
+	}
+}
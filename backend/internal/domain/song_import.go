@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"github.com/salmon822/test_task/models"
+)
+
+const (
+	// SongImportAtomic imports every row or none of them.
+	SongImportAtomic = "atomic"
+	// SongImportBestEffort imports the valid rows and reports the others.
+	SongImportBestEffort = "bestEffort"
+)
+
+const (
+	SongImportRowImported = "imported"
+	SongImportRowFailed   = "failed"
+	// SongImportRowSkipped marks valid rows left out because an atomic
+	// import failed.
+	SongImportRowSkipped = "skipped"
+)
+
+// SongImportOptions tell how the rows of an import are written. A dry run
+// goes through every step, the inserts included, and rolls them back.
+type SongImportOptions struct {
+	Mode   string
+	DryRun bool
+}
+
+// SongImportRow is a row read from an import, with the line it starts on.
+// Err is set instead of Song when the row cannot be read or is not valid.
+type SongImportRow struct {
+	Line int64
+	Song *Song
+	Err  error
+}
+
+// SongImportRows reads the rows of an import one by one, Next returns io.EOF
+// after the last row.
+type SongImportRows interface {
+	Next() (*SongImportRow, error)
+}
+
+// SongImportRowResult is the outcome of a row of an import.
+type SongImportRowResult struct {
+	Line   int64
+	Status string
+	SongID int64
+	Err    error
+}
+
+type SongImportReport struct {
+	Options   SongImportOptions
+	Committed bool
+	Total     int64
+	Imported  int64
+	Failed    int64
+	Rows      []*SongImportRowResult
+}
+
+func SongImportRowResultDomain2Models(r *SongImportRowResult) models.SongImportRow {
+	res := models.SongImportRow{
+		Line:   r.Line,
+		Status: models.SongImportRowStatus(r.Status),
+	}
+	if r.SongID != 0 {
+		res.Id = &r.SongID
+	}
+	if r.Err != nil {
+		message := r.Err.Error()
+		res.Error = &message
+	}
+	return res
+}
+
+func SongImportReportDomain2Models(r *SongImportReport) *models.SongImportReport {
+	if r == nil {
+		return nil
+	}
+	rows := make([]models.SongImportRow, 0, len(r.Rows))
+	for _, row := range r.Rows {
+		rows = append(rows, SongImportRowResultDomain2Models(row))
+	}
+	return &models.SongImportReport{
+		Mode:      models.SongImportMode(r.Options.Mode),
+		DryRun:    r.Options.DryRun,
+		Committed: r.Committed,
+		Total:     r.Total,
+		Imported:  r.Imported,
+		Failed:    r.Failed,
+		Rows:      rows,
+	}
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"slices"
+
+	"github.com/salmon822/test_task/models"
+)
+
+// Song fields a patch can set, as named in the API.
+const (
+	SongFieldGroupName   = "groupName"
+	SongFieldSongTitle   = "songTitle"
+	SongFieldReleaseDate = "releaseDate"
+	SongFieldSongText    = "songText"
+	SongFieldLink        = "link"
+	SongFieldAlbumID     = "albumId"
+	SongFieldDiscNumber  = "discNumber"
+	SongFieldTrackNumber = "trackNumber"
+)
+
+var songPatchFields = []string{
+	SongFieldGroupName, SongFieldSongTitle, SongFieldReleaseDate, SongFieldSongText, SongFieldLink,
+	SongFieldAlbumID, SongFieldDiscNumber, SongFieldTrackNumber,
+}
+
+// SongPatch sets the song fields listed in Fields to their value in Song.
+// Fields set to their zero value are cleared, unlike in a partial update
+// where they are left as they are.
+type SongPatch struct {
+	Song   Song
+	Fields []string
+}
+
+// SongPatcher computes the patch of a song from its current state, which
+// some patches depend on, e.g. JSON Patch tests.
+type SongPatcher func(song *Song) (*SongPatch, error)
+
+func (p *SongPatch) Has(field string) bool {
+	return slices.Contains(p.Fields, field)
+}
+
+// Apply sets the fields of the patch on the song. Leaving an album clears the
+// track position, joining one puts the song on its first disc unless told
+// otherwise.
+func (p *SongPatch) Apply(song *Song) *Song {
+	for _, field := range p.Fields {
+		switch field {
+		case SongFieldGroupName:
+			song.GroupName = p.Song.GroupName
+		case SongFieldSongTitle:
+			song.SongTitle = p.Song.SongTitle
+		case SongFieldReleaseDate:
+			song.ReleaseDate = p.Song.ReleaseDate
+		case SongFieldSongText:
+			song.SongText = p.Song.SongText
+		case SongFieldLink:
+			song.Link = p.Song.Link
+		case SongFieldAlbumID:
+			song.AlbumID = p.Song.AlbumID
+		case SongFieldDiscNumber:
+			song.DiscNumber = p.Song.DiscNumber
+		case SongFieldTrackNumber:
+			song.TrackNumber = p.Song.TrackNumber
+		}
+	}
+
+	if song.AlbumID == 0 {
+		song.DiscNumber, song.TrackNumber = 0, 0
+	} else if song.DiscNumber == 0 {
+		song.DiscNumber = 1
+	}
+
+	return song
+}
+
+// SongPatchModels2Domain converts the patch, leaving out the fields that
+// cannot be patched.
+func SongPatchModels2Domain(p *models.SongPatch) *SongPatch {
+	patch := &SongPatch{Song: *SongModels2Domain(&p.Song)}
+	for _, field := range p.Fields {
+		if slices.Contains(songPatchFields, field) {
+			patch.Fields = append(patch.Fields, field)
+		}
+	}
+	return patch
+}
@@ -0,0 +1,224 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/salmon822/test_task/models"
+)
+
+// SyncedLine is a line of time-synced lyrics shown from Start into the song.
+// An empty Text clears the line shown before it.
+type SyncedLine struct {
+	Number int64
+	Start  time.Duration
+	Text   string
+}
+
+// SyncedLyrics are the time-synced lyrics of a song, ordered by Start.
+type SyncedLyrics struct {
+	Song  Song
+	Lines []*SyncedLine
+}
+
+// SyncedLyricsPosition holds the line shown at a playback position and the
+// line shown after it, either is nil when there is none.
+type SyncedLyricsPosition struct {
+	Position time.Duration
+	Line     *SyncedLine
+	Next     *SyncedLine
+}
+
+var (
+	lrcTimeTagRegexp = regexp.MustCompile(`^\[(\d+):(\d+)(?:[.:](\d{1,3}))?\]`)
+	lrcIDTagRegexp   = regexp.MustCompile(`^\[([A-Za-z#]+):(.*)\]$`)
+)
+
+// ParseLRC parses lyrics in the LRC format. A line may carry several
+// timestamps when it is sung more than once, the first timestamps of the
+// lines must grow in the order the lines are written. ID tags other than
+// offset are ignored, the offset is applied to the timestamps.
+func ParseLRC(text string) ([]*SyncedLine, error) {
+	var (
+		lines     []*SyncedLine
+		offset    time.Duration
+		lastStart = time.Duration(-1)
+	)
+
+	for i, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		lineNumber := i + 1
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		if !lrcTimeTagRegexp.MatchString(raw) {
+			match := lrcIDTagRegexp.FindStringSubmatch(raw)
+			if match == nil {
+				return nil, fmt.Errorf("line %d: missing timestamp", lineNumber)
+			}
+			if strings.EqualFold(match[1], "offset") {
+				ms, err := strconv.ParseInt(strings.TrimSpace(match[2]), 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid offset %q", lineNumber, match[2])
+				}
+				offset = time.Duration(ms) * time.Millisecond
+			}
+			continue
+		}
+
+		var starts []time.Duration
+		for {
+			match := lrcTimeTagRegexp.FindStringSubmatch(raw)
+			if match == nil {
+				break
+			}
+			start, err := parseLRCTimestamp(match[1], match[2], match[3])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			if len(starts) > 0 && start <= starts[len(starts)-1] {
+				return nil, fmt.Errorf("line %d: timestamp %s is not after %s",
+					lineNumber, FormatLRCTimestamp(start), FormatLRCTimestamp(starts[len(starts)-1]))
+			}
+			starts = append(starts, start)
+			raw = raw[len(match[0]):]
+		}
+		if strings.HasPrefix(raw, "[") && lrcTimeTagPrefix(raw) {
+			return nil, fmt.Errorf("line %d: invalid timestamp in %q", lineNumber, raw)
+		}
+
+		if starts[0] <= lastStart {
+			return nil, fmt.Errorf("line %d: timestamp %s is not after %s",
+				lineNumber, FormatLRCTimestamp(starts[0]), FormatLRCTimestamp(lastStart))
+		}
+		lastStart = starts[0]
+
+		for _, start := range starts {
+			lines = append(lines, &SyncedLine{
+				Start: start,
+				Text:  strings.TrimSpace(raw),
+			})
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("no timed lines")
+	}
+
+	for _, line := range lines {
+		line.Start -= offset
+		if line.Start < 0 {
+			line.Start = 0
+		}
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Start < lines[j].Start
+	})
+	for i, line := range lines {
+		if i > 0 && line.Start == lines[i-1].Start {
+			return nil, fmt.Errorf("two lines start at %s", FormatLRCTimestamp(line.Start))
+		}
+		line.Number = int64(i + 1)
+	}
+
+	return lines, nil
+}
+
+// lrcTimeTagPrefix reports whether the text starts with something meant to be
+// a timestamp, e.g. "[01:75.00]" or "[1:2:3]".
+func lrcTimeTagPrefix(text string) bool {
+	end := strings.Index(text, "]")
+	if end < 0 {
+		return false
+	}
+	tag := text[1:end]
+	return tag != "" && strings.Trim(tag, "0123456789:.") == ""
+}
+
+func parseLRCTimestamp(minutes, seconds, fraction string) (time.Duration, error) {
+	m, err := strconv.ParseInt(minutes, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid minutes %q", minutes)
+	}
+	s, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil || s >= 60 {
+		return 0, fmt.Errorf("invalid seconds %q", seconds)
+	}
+
+	var ms int64
+	if fraction != "" {
+		ms, _ = strconv.ParseInt(fraction+strings.Repeat("0", 3-len(fraction)), 10, 64)
+	}
+
+	return time.Duration(m)*time.Minute + time.Duration(s)*time.Second + time.Duration(ms)*time.Millisecond, nil
+}
+
+// FormatLRCTimestamp formats the position as mm:ss.xx, with milliseconds when
+// hundredths are not precise enough.
+func FormatLRCTimestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+	minutes, seconds, fraction := ms/60000, ms/1000%60, ms%1000
+	if fraction%10 == 0 {
+		return fmt.Sprintf("%02d:%02d.%02d", minutes, seconds, fraction/10)
+	}
+	return fmt.Sprintf("%02d:%02d.%03d", minutes, seconds, fraction)
+}
+
+// FormatLRC writes the synced lyrics in the LRC format.
+func FormatLRC(l *SyncedLyrics) string {
+	var b strings.Builder
+	if l.Song.GroupName != "" {
+		fmt.Fprintf(&b, "[ar:%s]\n", l.Song.GroupName)
+	}
+	if l.Song.SongTitle != "" {
+		fmt.Fprintf(&b, "[ti:%s]\n", l.Song.SongTitle)
+	}
+	for _, line := range l.Lines {
+		fmt.Fprintf(&b, "[%s]%s\n", FormatLRCTimestamp(line.Start), line.Text)
+	}
+
+	return b.String()
+}
+
+func SyncedLineDomain2Models(l *SyncedLine) *models.SyncedLine {
+	if l == nil {
+		return nil
+	}
+	return &models.SyncedLine{
+		Number:    l.Number,
+		Time:      l.Start.Seconds(),
+		Timestamp: FormatLRCTimestamp(l.Start),
+		Text:      l.Text,
+	}
+}
+
+func SyncedLyricsDomain2Models(l *SyncedLyrics) *models.SyncedLyrics {
+	if l == nil {
+		return nil
+	}
+	lines := make([]models.SyncedLine, 0, len(l.Lines))
+	for _, line := range l.Lines {
+		lines = append(lines, *SyncedLineDomain2Models(line))
+	}
+	return &models.SyncedLyrics{
+		SongId: l.Song.ID,
+		Lines:  lines,
+	}
+}
+
+func SyncedLyricsPositionDomain2Models(p *SyncedLyricsPosition) *models.SyncedLyricsPosition {
+	if p == nil {
+		return nil
+	}
+	return &models.SyncedLyricsPosition{
+		T:    p.Position.Seconds(),
+		Line: SyncedLineDomain2Models(p.Line),
+		Next: SyncedLineDomain2Models(p.Next),
+	}
+}
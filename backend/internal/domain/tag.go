@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"github.com/salmon822/test_task/models"
+)
+
+const (
+	TagKindGenre = "genre"
+	TagKindTag   = "tag"
+)
+
+type Tag struct {
+	ID   int64
+	Name string
+	Kind string
+}
+
+func TagDomain2Models(t *Tag) *models.Tag {
+	if t == nil {
+		return nil
+	}
+	kind := models.TagKind(t.Kind)
+	return &models.Tag{
+		Id:   &t.ID,
+		Name: t.Name,
+		Kind: &kind,
+	}
+}
+
+func TagModels2Domain(t models.Tag) *Tag {
+	tag := &Tag{
+		Name: t.Name,
+		Kind: TagKindTag,
+	}
+	if t.Id != nil {
+		tag.ID = *t.Id
+	}
+	if t.Kind != nil {
+		tag.Kind = string(*t.Kind)
+	}
+
+	return tag
+}
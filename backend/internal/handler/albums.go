@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/handler/writes"
+	"github.com/salmon822/test_task/models"
+)
+
+func (h *handler) createAlbum(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	var req models.AlbumCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Errorf("Failed to decode request body: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to decode request body: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	if err := req.Validate(h.validationFormats); err != nil {
+		h.logger.Errorf("Validation failed: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("%w: %w", domain.ErrValidation, err))
+		return
+	}
+
+	res, err := h.albums.CreateAlbum(ctx, domain.AlbumModels2Domain(req.Album))
+	if err != nil {
+		h.logger.Errorf("Failed to create album: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to create album: %w", err))
+		return
+	}
+
+	h.logger.Infof("Album created successfully with ID: %d", res.ID)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.AlbumDomain2Models(res))
+}
+
+func (h *handler) getAlbum(w http.ResponseWriter, r *http.Request) {
+	var id int64
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.albums.GetAlbum(ctx, id)
+	if err != nil {
+		h.logger.Errorf("Failed to get album with ID %d: %v", id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to get album: %w", err))
+		return
+	}
+
+	h.logger.Infof("Retrieved album successfully with ID: %d", id)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.AlbumDomain2Models(res))
+}
+
+func (h *handler) getAlbumTracks(w http.ResponseWriter, r *http.Request) {
+	var id int64
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.albums.GetAlbumTracks(ctx, id)
+	if err != nil {
+		h.logger.Errorf("Failed to get tracks for album ID %d: %v", id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to get album tracks: %w", err))
+		return
+	}
+
+	h.logger.Infof("Retrieved tracks successfully for album ID: %d", id)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.AlbumTracksDomain2Models(res))
+}
+
+func (h *handler) reorderAlbumTracks(w http.ResponseWriter, r *http.Request) {
+	var id int64
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	var req models.AlbumTracksReorderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Errorf("Failed to decode request body: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to decode request body: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	if err := req.Validate(h.validationFormats); err != nil {
+		h.logger.Errorf("Validation failed: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("%w: %w", domain.ErrValidation, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.albums.ReorderAlbumTracks(ctx, id, domain.MapSlice(req.Tracks, domain.TrackPositionModels2Domain))
+	if err != nil {
+		h.logger.Errorf("Failed to reorder tracks for album ID %d: %v", id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to reorder album tracks: %w", err))
+		return
+	}
+
+	h.logger.Infof("Reordered tracks successfully for album ID: %d", id)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.AlbumTracksDomain2Models(res))
+}
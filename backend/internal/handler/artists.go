@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/handler/writes"
+	"github.com/salmon822/test_task/models"
+)
+
+func (h *handler) createArtist(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	var req models.ArtistCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Errorf("Failed to decode request body: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to decode request body: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	if err := req.Validate(h.validationFormats); err != nil {
+		h.logger.Errorf("Validation failed: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("%w: %w", domain.ErrValidation, err))
+		return
+	}
+
+	res, err := h.artists.CreateArtist(ctx, domain.ArtistModels2Domain(req.Artist))
+	if err != nil {
+		h.logger.Errorf("Failed to create artist: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to create artist: %w", err))
+		return
+	}
+
+	h.logger.Infof("Artist created successfully with ID: %d", res.ID)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.ArtistDomain2Models(res))
+}
+
+func (h *handler) getArtist(w http.ResponseWriter, r *http.Request) {
+	var id int64
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.artists.GetArtist(ctx, id)
+	if err != nil {
+		h.logger.Errorf("Failed to get artist with ID %d: %v", id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to get artist: %w", err))
+		return
+	}
+
+	h.logger.Infof("Retrieved artist successfully with ID: %d", id)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.ArtistDomain2Models(res))
+}
+
+func (h *handler) updateArtist(w http.ResponseWriter, r *http.Request) {
+	var id int64
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	var req models.ArtistUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Errorf("Failed to decode request body: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to decode request body: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	if err := req.Validate(h.validationFormats); err != nil {
+		h.logger.Errorf("Validation failed: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("%w: %w", domain.ErrValidation, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.artists.UpdateArtist(ctx, id, domain.ArtistModels2Domain(req.Artist))
+	if err != nil {
+		h.logger.Errorf("Failed to update artist with ID %d: %v", id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to update artist: %w", err))
+		return
+	}
+
+	h.logger.Infof("Artist updated successfully with ID: %d", id)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.ArtistDomain2Models(res))
+}
+
+func (h *handler) deleteArtist(w http.ResponseWriter, r *http.Request) {
+	var id int64
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	if err := h.artists.DeleteArtist(ctx, id); err != nil {
+		h.logger.Errorf("Failed to delete artist with ID %d: %v", id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to delete artist: %w", err))
+		return
+	}
+
+	h.logger.Infof("Artist deleted successfully with ID: %d", id)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, successResponse(true))
+}
+
+func (h *handler) getArtists(w http.ResponseWriter, r *http.Request) {
+	var filters domain.ArtistFilters
+	defer r.Body.Close()
+
+	if err := h.parseQueryStringParam(r, "name", &filters.Name); err != nil {
+		h.logger.Errorf("Failed to parse name: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	page, err := h.parseQueryInt64Param(r, "page", 1)
+	if err != nil {
+		h.logger.Errorf("Failed to parse page: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+	pageSize, err := h.parseQueryInt64Param(r, "pageSize", 5)
+	if err != nil {
+		h.logger.Errorf("Failed to parse pageSize: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.artists.GetArtists(ctx, &filters, page, pageSize)
+	if err != nil {
+		h.logger.Errorf("Failed to get artists: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to get artists: %w", err))
+		return
+	}
+
+	h.logger.Infof("Retrieved artists successfully")
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.MapSlice(res, domain.ArtistDomain2Models))
+}
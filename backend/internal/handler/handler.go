@@ -3,22 +3,40 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/gorilla/mux"
 	"github.com/salmon822/test_task/internal/config"
+	"github.com/salmon822/test_task/internal/domain"
 	"github.com/salmon822/test_task/internal/pkg/logger"
+	"github.com/salmon822/test_task/internal/pkg/requestid"
 	"github.com/salmon822/test_task/internal/service"
 	"github.com/salmon822/test_task/models"
 )
 
+var (
+	// legacyRoutesDeprecatedAt is when the verb-style routes were deprecated
+	// in favour of /api/v1.
+	legacyRoutesDeprecatedAt = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	// legacyRoutesSunsetAt is when the verb-style routes are to be removed.
+	legacyRoutesSunsetAt = time.Date(2027, time.April, 18, 0, 0, 0, 0, time.UTC)
+)
+
 type Handler interface {
 	Init() http.Handler
 }
 
 type handler struct {
 	songs             service.Songs
+	artists           service.Artists
+	albums            service.Albums
+	tags              service.Tags
+	songLyrics        service.SongLyrics
+	syncedLyrics      service.SyncedLyrics
 	cfg               *config.HandlerConfig
 	logger            logger.Logger
 	validationFormats strfmt.Registry
@@ -26,11 +44,21 @@ type handler struct {
 
 func NewHandler(
 	songs service.Songs,
+	artists service.Artists,
+	albums service.Albums,
+	tags service.Tags,
+	songLyrics service.SongLyrics,
+	syncedLyrics service.SyncedLyrics,
 	cfg *config.HandlerConfig,
 	logger logger.Logger,
 ) Handler {
 	return &handler{
 		songs:             songs,
+		artists:           artists,
+		albums:            albums,
+		tags:              tags,
+		songLyrics:        songLyrics,
+		syncedLyrics:      syncedLyrics,
 		cfg:               cfg,
 		logger:            logger,
 		validationFormats: strfmt.NewFormats(),
@@ -43,6 +71,7 @@ func (h *handler) corsMiddleware(next http.Handler) http.Handler {
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
 		w.Header().Set("Access-Control-Allow-Headers", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "*")
+		w.Header().Set("Access-Control-Expose-Headers", "ETag, Accept-Patch, Deprecation, Sunset, Link, "+requestid.Header)
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusOK)
 			return
@@ -51,12 +80,79 @@ func (h *handler) corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// requestIDMiddleware keeps the request ID sent by the client or makes a new
+// one, and sends it back so that errors can be matched with the logs.
+func (h *handler) requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestid.Header)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+		w.Header().Set(requestid.Header, id)
+		next.ServeHTTP(w, r.WithContext(requestid.NewContext(r.Context(), id)))
+	})
+}
+
+// deprecated serves a deprecated alias of the route at successor, announcing
+// the deprecation (RFC 9745) and the removal (RFC 8594) of the alias. The
+// {id} of successor is replaced with the ID of the request.
+func (h *handler) deprecated(successor string, next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		link := strings.ReplaceAll(successor, "{id}", url.PathEscape(mux.Vars(r)["id"]))
+
+		w.Header().Set("Deprecation", "@"+strconv.FormatInt(legacyRoutesDeprecatedAt.Unix(), 10))
+		w.Header().Set("Sunset", legacyRoutesSunsetAt.Format(http.TimeFormat))
+		w.Header().Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, link))
+		next(w, r)
+	})
+}
+
 func successResponse(s bool) *models.SuccessResponse {
 	return &models.SuccessResponse{
 		Success: &s,
 	}
 }
 
+// setSongETag sends the version of the song as its entity tag.
+func setSongETag(w http.ResponseWriter, song *domain.Song) {
+	w.Header().Set("ETag", `"`+strconv.FormatInt(song.Version, 10)+`"`)
+}
+
+// setSongWeakETag sends the version of the song as a weak entity tag, for
+// representations that are not the full song. parseIfMatch never matches it.
+func setSongWeakETag(w http.ResponseWriter, song *domain.Song) {
+	w.Header().Set("ETag", `W/"`+strconv.FormatInt(song.Version, 10)+`"`)
+}
+
+// parseIfMatch reads the song versions listed in If-Match, it returns nil
+// when the header is not sent. Weak and unknown tags never match, as If-Match
+// uses the strong comparison.
+func parseIfMatch(r *http.Request) *domain.SongVersionMatch {
+	values := r.Header.Values("If-Match")
+	if len(values) == 0 {
+		return nil
+	}
+
+	match := &domain.SongVersionMatch{}
+	for _, value := range values {
+		for _, tag := range strings.Split(value, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" {
+				match.Any = true
+				continue
+			}
+			if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+				continue
+			}
+			if version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64); err == nil {
+				match.Versions = append(match.Versions, version)
+			}
+		}
+	}
+
+	return match
+}
+
 func (h *handler) parsePathInt64Param(r *http.Request, paramName string, paramValue *int64) error {
 	param := mux.Vars(r)[paramName]
 	if param == "" {
@@ -86,6 +182,85 @@ func (h *handler) parseQueryInt64Param(r *http.Request, paramName string, defaul
 	return paramValue, nil
 }
 
+// parsePageParams reads the page and pageSize query parameters, both must be
+// positive.
+func (h *handler) parsePageParams(r *http.Request, defaultPageSize int64) (int64, int64, error) {
+	page, err := h.parseQueryInt64Param(r, "page", 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	if page < 1 {
+		return 0, 0, fmt.Errorf("page must be positive")
+	}
+	pageSize, err := h.parseQueryInt64Param(r, "pageSize", defaultPageSize)
+	if err != nil {
+		return 0, 0, err
+	}
+	if pageSize < 1 {
+		return 0, 0, fmt.Errorf("pageSize must be positive")
+	}
+
+	return page, pageSize, nil
+}
+
+func (h *handler) parseQueryOptionalInt64Param(r *http.Request, paramName string, dest **int64) error {
+	if r.URL.Query().Get(paramName) == "" {
+		return nil
+	}
+
+	paramValue, err := h.parseQueryInt64Param(r, paramName, 0)
+	if err != nil {
+		return err
+	}
+
+	*dest = &paramValue
+	return nil
+}
+
+func (h *handler) parseQueryBoolParam(r *http.Request, paramName string, dest *bool) error {
+	param := r.URL.Query().Get(paramName)
+	if param == "" {
+		return nil
+	}
+
+	paramValue, err := strconv.ParseBool(param)
+	if err != nil {
+		return fmt.Errorf("parseQueryBoolParam: %w", err)
+	}
+
+	*dest = paramValue
+	return nil
+}
+
+func (h *handler) parseQueryDateParam(r *http.Request, paramName string, dest **time.Time) error {
+	param := r.URL.Query().Get(paramName)
+	if param == "" {
+		return nil
+	}
+
+	date, err := models.ParseDate(param)
+	if err != nil {
+		return fmt.Errorf("parseQueryDateParam: %s: %w", paramName, err)
+	}
+
+	*dest = &date
+	return nil
+}
+
+func (h *handler) parseQueryStringListParam(r *http.Request, paramName string, dest *[]string) error {
+	param := r.URL.Query().Get(paramName)
+	if param == "" {
+		return nil
+	}
+
+	for _, value := range strings.Split(param, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			*dest = append(*dest, value)
+		}
+	}
+	return nil
+}
+
 func (h *handler) parseQueryStringParam(r *http.Request, paramName string, dest **string) error {
 	param := r.URL.Query().Get(paramName)
 	if param == "" {
@@ -99,13 +274,61 @@ func (h *handler) parseQueryStringParam(r *http.Request, paramName string, dest
 func (h *handler) Init() http.Handler {
 	router := mux.NewRouter()
 
+	v1SongsRouter := router.PathPrefix("/api/v1/songs").Subrouter()
+	v1SongsRouter.Handle("", http.HandlerFunc(h.getFilteredSongs)).Methods(http.MethodGet)
+	v1SongsRouter.Handle("", http.HandlerFunc(h.createSong)).Methods(http.MethodPost)
+	h.songSubresourceRoutes(v1SongsRouter)
+	v1SongsRouter.Handle("/{id}", http.HandlerFunc(h.deleteSong)).Methods(http.MethodDelete)
+	v1SongsRouter.Handle("/{id}/text", http.HandlerFunc(h.getSongText)).Methods(http.MethodGet)
+
+	// The verb-style routes predate /api/v1 and are kept as deprecated
+	// aliases of their resource routes.
 	songsRouter := router.PathPrefix("/songs").Subrouter()
-	songsRouter.Handle("/create", http.HandlerFunc(h.createSong)).Methods(http.MethodPost)
-	songsRouter.Handle("/{id}/delete", http.HandlerFunc(h.deleteSong)).Methods(http.MethodDelete)
-	songsRouter.Handle("/{id}/update", http.HandlerFunc(h.updateSong)).Methods(http.MethodPatch)
-	songsRouter.Handle("/{id}/song-text", http.HandlerFunc(h.getSongText)).Methods(http.MethodPost)
-	songsRouter.Handle("/filter", http.HandlerFunc(h.getFilteredSongs)).Methods(http.MethodGet)
+	songsRouter.Handle("/create", h.deprecated("/api/v1/songs", h.createSong)).Methods(http.MethodPost)
+	songsRouter.Handle("/filter", h.deprecated("/api/v1/songs", h.getFilteredSongs)).Methods(http.MethodGet)
+	songsRouter.Handle("/{id}/delete", h.deprecated("/api/v1/songs/{id}", h.deleteSong)).Methods(http.MethodDelete)
+	songsRouter.Handle("/{id}/update", h.deprecated("/api/v1/songs/{id}", h.updateSong)).Methods(http.MethodPatch)
+	songsRouter.Handle("/{id}/song-text", h.deprecated("/api/v1/songs/{id}/text", h.getSongText)).Methods(http.MethodPost)
+	h.songSubresourceRoutes(songsRouter)
 
-	router.Use(h.corsMiddleware)
+	artistsRouter := router.PathPrefix("/artists").Subrouter()
+	artistsRouter.Handle("/create", http.HandlerFunc(h.createArtist)).Methods(http.MethodPost)
+	artistsRouter.Handle("/filter", http.HandlerFunc(h.getArtists)).Methods(http.MethodGet)
+	artistsRouter.Handle("/{id}", http.HandlerFunc(h.getArtist)).Methods(http.MethodGet)
+	artistsRouter.Handle("/{id}/update", http.HandlerFunc(h.updateArtist)).Methods(http.MethodPatch)
+	artistsRouter.Handle("/{id}/delete", http.HandlerFunc(h.deleteArtist)).Methods(http.MethodDelete)
+
+	albumsRouter := router.PathPrefix("/albums").Subrouter()
+	albumsRouter.Handle("/create", http.HandlerFunc(h.createAlbum)).Methods(http.MethodPost)
+	albumsRouter.Handle("/{id}", http.HandlerFunc(h.getAlbum)).Methods(http.MethodGet)
+	albumsRouter.Handle("/{id}/tracks", http.HandlerFunc(h.getAlbumTracks)).Methods(http.MethodGet)
+	albumsRouter.Handle("/{id}/tracks/order", http.HandlerFunc(h.reorderAlbumTracks)).Methods(http.MethodPut)
+
+	router.Use(h.corsMiddleware, h.requestIDMiddleware)
 	return router
 }
+
+// songSubresourceRoutes registers the song routes served both under /api/v1
+// and at the root. Fixed paths come before the ones matching any song ID.
+func (h *handler) songSubresourceRoutes(songsRouter *mux.Router) {
+	songsRouter.Handle("/search", http.HandlerFunc(h.searchSongs)).Methods(http.MethodGet)
+	songsRouter.Handle("/import", http.HandlerFunc(h.importSongs)).Methods(http.MethodPost)
+	songsRouter.Handle("/batch", http.HandlerFunc(h.applySongBatch)).Methods(http.MethodPost)
+	songsRouter.Handle("/export", http.HandlerFunc(h.exportSongs)).Methods(http.MethodGet)
+	songsRouter.Handle("/{id}", http.HandlerFunc(h.getSong)).Methods(http.MethodGet)
+	songsRouter.Handle("/{id}", http.HandlerFunc(h.patchSong)).Methods(http.MethodPatch)
+	songsRouter.Handle("/{id}/restore", http.HandlerFunc(h.restoreSong)).Methods(http.MethodPost)
+	songsRouter.Handle("/{id}/tags", http.HandlerFunc(h.getSongTags)).Methods(http.MethodGet)
+	songsRouter.Handle("/{id}/tags", http.HandlerFunc(h.addSongTags)).Methods(http.MethodPost)
+	songsRouter.Handle("/{id}/tags", http.HandlerFunc(h.replaceSongTags)).Methods(http.MethodPut)
+	songsRouter.Handle("/{id}/tags/{tagId}", http.HandlerFunc(h.removeSongTag)).Methods(http.MethodDelete)
+	songsRouter.Handle("/{id}/revisions", http.HandlerFunc(h.getSongRevisions)).Methods(http.MethodGet)
+	songsRouter.Handle("/{id}/revisions/{rev}/restore", http.HandlerFunc(h.restoreSongRevision)).Methods(http.MethodPost)
+	songsRouter.Handle("/{id}/lyrics/lrc", http.HandlerFunc(h.getSyncedLyrics)).Methods(http.MethodGet)
+	songsRouter.Handle("/{id}/lyrics/lrc", http.HandlerFunc(h.replaceSyncedLyrics)).Methods(http.MethodPut)
+	songsRouter.Handle("/{id}/lyrics/at", http.HandlerFunc(h.getSyncedLyricsAt)).Methods(http.MethodGet)
+	songsRouter.Handle("/{id}/lyrics/translations", http.HandlerFunc(h.getSongTranslations)).Methods(http.MethodGet)
+	songsRouter.Handle("/{id}/lyrics/translations/{lang}", http.HandlerFunc(h.getSongTranslation)).Methods(http.MethodGet)
+	songsRouter.Handle("/{id}/lyrics/translations/{lang}", http.HandlerFunc(h.putSongTranslation)).Methods(http.MethodPut)
+	songsRouter.Handle("/{id}/lyrics/translations/{lang}", http.HandlerFunc(h.deleteSongTranslation)).Methods(http.MethodDelete)
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/handler/writes"
+	"github.com/salmon822/test_task/internal/pkg/requestid"
+	"github.com/salmon822/test_task/models"
+)
+
+func (h *handler) applySongBatch(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	var req models.SongBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Errorf("Failed to decode request body: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to decode request body: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	if err := req.Validate(h.validationFormats); err != nil {
+		h.logger.Errorf("Validation failed: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("%w: %w", domain.ErrValidation, err))
+		return
+	}
+
+	mode := domain.SongBatchAtomic
+	if req.Mode != nil {
+		mode = string(*req.Mode)
+	}
+
+	// Invalid operations are reported in their result, so that they only fail
+	// themselves in an independent batch.
+	operations := make([]*domain.SongBatchOperation, 0, len(req.Operations))
+	for i := range req.Operations {
+		operation := domain.SongBatchOperationModels2Domain(&req.Operations[i])
+		if err := req.ValidateOperation(i, h.validationFormats); err != nil {
+			operation.Err = fmt.Errorf("%w: %w", domain.ErrValidation, err)
+		}
+		operations = append(operations, operation)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	report, err := h.songs.ApplySongBatch(ctx, mode, operations)
+	if err != nil {
+		h.logger.Errorf("Failed to apply song batch: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to apply song batch: %w", err))
+		return
+	}
+
+	code := int64(http.StatusOK)
+	if mode == domain.SongBatchAtomic && !report.Committed {
+		code = http.StatusUnprocessableEntity
+	}
+
+	h.logger.Infof("Applied %d of %d song batch operations (%s)", report.Succeeded, len(operations), mode)
+	writes.WriteResponseWithErrorLog(w, code, domain.SongBatchReportDomain2Models(report, func(err error) models.ErrorResponse {
+		problem := writes.Problem(r, err)
+		if problem.Type == models.InternalError {
+			h.logger.Errorf("request %s: song batch operation failed: %v", requestid.FromContext(r.Context()), err)
+		}
+		return problem
+	}))
+}
@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/handler/writes"
+	"github.com/salmon822/test_task/models"
+)
+
+// exportFlushInterval is the number of songs written between flushes of the
+// response.
+const exportFlushInterval = 100
+
+// exportContentTypes maps the export formats to their media type.
+var exportContentTypes = map[models.GetSongsExportParamsFormat]string{
+	models.GetSongsExportParamsFormatCsv:    "text/csv; charset=utf-8",
+	models.GetSongsExportParamsFormatJson:   "application/json",
+	models.GetSongsExportParamsFormatNdjson: "application/x-ndjson",
+}
+
+// csvExportColumns are the columns of a CSV export.
+var csvExportColumns = []string{
+	"id", "groupName", "songTitle", "releaseDate", "songText", "link",
+	"albumId", "discNumber", "trackNumber", "enrichmentStatus", "createdAt", "updatedAt",
+}
+
+func (h *handler) exportSongs(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	format := models.GetSongsExportParamsFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		format = models.GetSongsExportParamsFormatJson
+	}
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		err := fmt.Errorf("format must be %s, %s or %s, got %q", models.GetSongsExportParamsFormatCsv,
+			models.GetSongsExportParamsFormatJson, models.GetSongsExportParamsFormatNdjson, format)
+		h.logger.Errorf("Failed to parse export format: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	filters, err := h.parseSongFilters(r)
+	if err != nil {
+		h.logger.Errorf("Failed to parse filters: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+	sort, err := parseSongSort(r.URL.Query().Get("sort"))
+	if err != nil {
+		h.logger.Errorf("Failed to parse sort: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.ExportTimeout)
+	defer cancel()
+
+	// The server write timeout is meant for regular responses, an export may
+	// take as long as its own timeout. Writers that cannot extend it are used
+	// as they are.
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(h.cfg.ExportTimeout))
+
+	export := newSongExportWriter(w, format, contentType)
+
+	err = h.songs.ExportSongs(ctx, filters, sort, export.write)
+	if err == nil {
+		err = export.close()
+	}
+	if err != nil {
+		if !export.started {
+			h.logger.Errorf("Failed to export songs: %v", err)
+			writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to export songs: %w", err))
+			return
+		}
+		// The status is sent already, the client can only tell the export
+		// is incomplete from the connection being cut.
+		h.logger.Errorf("Song export aborted after %d songs: %v", export.count, err)
+		panic(http.ErrAbortHandler)
+	}
+
+	h.logger.Infof("Exported %d songs as %s", export.count, format)
+}
+
+// songExportWriter writes the songs of an export to the response as they
+// come. Nothing is sent before the first song, so that an export failing
+// early still gets an error response.
+type songExportWriter struct {
+	w           http.ResponseWriter
+	flusher     http.Flusher
+	buf         *bufio.Writer
+	csv         *csv.Writer
+	format      models.GetSongsExportParamsFormat
+	contentType string
+	started     bool
+	count       int64
+}
+
+func newSongExportWriter(w http.ResponseWriter, format models.GetSongsExportParamsFormat, contentType string) *songExportWriter {
+	flusher, _ := w.(http.Flusher)
+	return &songExportWriter{w: w, flusher: flusher, format: format, contentType: contentType}
+}
+
+// start sends the headers and opens the document.
+func (e *songExportWriter) start() error {
+	e.started = true
+
+	header := e.w.Header()
+	header.Set("Content-Type", e.contentType)
+	header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="songs.%s"`, e.format))
+	e.w.WriteHeader(http.StatusOK)
+
+	e.buf = bufio.NewWriter(e.w)
+	switch e.format {
+	case models.GetSongsExportParamsFormatCsv:
+		e.csv = csv.NewWriter(e.buf)
+		return e.csv.Write(csvExportColumns)
+	case models.GetSongsExportParamsFormatJson:
+		_, err := e.buf.WriteString("[")
+		return err
+	}
+
+	return nil
+}
+
+func (e *songExportWriter) write(song *domain.Song) error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	switch e.format {
+	case models.GetSongsExportParamsFormatCsv:
+		err = e.csv.Write(songCSVRecord(song))
+	case models.GetSongsExportParamsFormatJson:
+		if e.count > 0 {
+			if _, err := e.buf.WriteString(","); err != nil {
+				return err
+			}
+		}
+		err = writeJSON(e.buf, domain.SongDomain2Models(song), false)
+	default:
+		err = writeJSON(e.buf, domain.SongDomain2Models(song), true)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.count++
+	if e.count%exportFlushInterval == 0 {
+		return e.flush()
+	}
+
+	return nil
+}
+
+// close ends the document and sends what is left of it.
+func (e *songExportWriter) close() error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	if e.format == models.GetSongsExportParamsFormatJson {
+		if _, err := e.buf.WriteString("]\n"); err != nil {
+			return err
+		}
+	}
+
+	return e.flush()
+}
+
+func (e *songExportWriter) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if err := e.buf.Flush(); err != nil {
+		return err
+	}
+	if e.flusher != nil {
+		e.flusher.Flush()
+	}
+
+	return nil
+}
+
+// writeJSON writes the value as JSON, followed by a newline when newline is
+// set.
+func writeJSON(w io.Writer, v any, newline bool) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if newline {
+		data = append(data, '\n')
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func songCSVRecord(song *domain.Song) []string {
+	var releaseDate string
+	if !song.ReleaseDate.IsZero() {
+		releaseDate = song.ReleaseDate.Format(models.DateFormat)
+	}
+
+	return []string{
+		strconv.FormatInt(song.ID, 10),
+		song.GroupName,
+		song.SongTitle,
+		releaseDate,
+		song.SongText,
+		song.Link,
+		formatOptionalInt(song.AlbumID),
+		formatOptionalInt(song.DiscNumber),
+		formatOptionalInt(song.TrackNumber),
+		song.EnrichmentStatus,
+		strconv.FormatInt(song.CreatedAt, 10),
+		strconv.FormatInt(song.UpdatedAt, 10),
+	}
+}
+
+// formatOptionalInt formats the number, leaving zero, which stands for an
+// unset value, empty.
+func formatOptionalInt(n int64) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.FormatInt(n, 10)
+}
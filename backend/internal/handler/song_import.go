@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/handler/writes"
+	"github.com/salmon822/test_task/models"
+)
+
+// maxImportSize bounds the size of an import body.
+const maxImportSize = 256 << 20
+
+// importContentTypes maps the media types of import bodies to their format.
+var importContentTypes = map[string]models.PostSongsImportParamsFormat{
+	"text/csv":             models.Csv,
+	"application/x-ndjson": models.Ndjson,
+	"application/ndjson":   models.Ndjson,
+	"application/jsonl":    models.Ndjson,
+}
+
+func (h *handler) importSongs(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	format, err := importFormat(r)
+	if err != nil {
+		h.logger.Errorf("Failed to parse import format: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	options := domain.SongImportOptions{Mode: domain.SongImportAtomic}
+	if mode := r.URL.Query().Get("mode"); mode != "" {
+		if mode != domain.SongImportAtomic && mode != domain.SongImportBestEffort {
+			err := fmt.Errorf("mode must be %s or %s, got %q", domain.SongImportAtomic, domain.SongImportBestEffort, mode)
+			h.logger.Errorf("Failed to parse mode: %v", err)
+			writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+			return
+		}
+		options.Mode = mode
+	}
+	if err := h.parseQueryBoolParam(r, "dryRun", &options.DryRun); err != nil {
+		h.logger.Errorf("Failed to parse dryRun: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	// The server timeouts are meant for regular requests, reading a large body
+	// and writing its report may take as long as the import timeout. Writers
+	// that cannot extend them are used as they are.
+	deadline := time.Now().Add(h.cfg.ImportTimeout)
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(deadline)
+	_ = rc.SetWriteDeadline(deadline)
+
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	var rows domain.SongImportRows
+	switch format {
+	case models.Csv:
+		rows, err = newCSVSongRows(body, h.validationFormats)
+	default:
+		rows = newNDJSONSongRows(body, h.validationFormats)
+	}
+	if err != nil {
+		h.logger.Errorf("Failed to read CSV header: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.ImportTimeout)
+	defer cancel()
+
+	report, err := h.songs.ImportSongs(ctx, rows, options)
+	if err != nil {
+		h.logger.Errorf("Failed to import songs: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to import songs: %w", err))
+		return
+	}
+
+	code := int64(http.StatusOK)
+	if !report.Committed && !options.DryRun {
+		code = http.StatusUnprocessableEntity
+	}
+
+	h.logger.Infof("Imported %d of %d songs (%s, dry run: %t)", report.Imported, report.Total, options.Mode, options.DryRun)
+	writes.WriteResponseWithErrorLog(w, code, domain.SongImportReportDomain2Models(report))
+}
+
+// importFormat reads the format of the body from the format parameter, or
+// from Content-Type when it is not set.
+func importFormat(r *http.Request) (models.PostSongsImportParamsFormat, error) {
+	if format := models.PostSongsImportParamsFormat(r.URL.Query().Get("format")); format != "" {
+		if format != models.Csv && format != models.Ndjson {
+			return "", fmt.Errorf("format must be %s or %s, got %q", models.Csv, models.Ndjson, format)
+		}
+		return format, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", fmt.Errorf("format is not set and Content-Type is invalid: %w", err)
+	}
+	format, ok := importContentTypes[mediaType]
+	if !ok {
+		return "", fmt.Errorf("format is not set and Content-Type %q is not supported", mediaType)
+	}
+
+	return format, nil
+}
+
+// importRow validates the song read from a row and converts it.
+func importRow(line int64, song *models.Song, formats strfmt.Registry) *domain.SongImportRow {
+	if err := song.Validate(formats); err != nil {
+		return &domain.SongImportRow{Line: line, Err: fmt.Errorf("%w: %w", domain.ErrValidation, err)}
+	}
+
+	return &domain.SongImportRow{Line: line, Song: domain.SongModels2Domain(song)}
+}
+
+// csvSongRows reads songs from CSV with a header row naming the song fields
+// of the columns, as they are named in JSON.
+type csvSongRows struct {
+	reader  *csv.Reader
+	columns []string
+	formats strfmt.Registry
+}
+
+var csvSongColumns = map[string]struct{}{
+	"groupName": {}, "songTitle": {}, "releaseDate": {}, "songText": {}, "link": {},
+	"albumId": {}, "discNumber": {}, "trackNumber": {},
+}
+
+func newCSVSongRows(r io.Reader, formats strfmt.Registry) (*csvSongRows, error) {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("the CSV header is missing")
+		}
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	seen := make(map[string]struct{}, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		if _, ok := csvSongColumns[column]; !ok {
+			return nil, fmt.Errorf("unknown CSV column %q", column)
+		}
+		if _, ok := seen[column]; ok {
+			return nil, fmt.Errorf("duplicate CSV column %q", column)
+		}
+		seen[column] = struct{}{}
+		header[i] = column
+	}
+
+	return &csvSongRows{reader: reader, columns: header, formats: formats}, nil
+}
+
+func (c *csvSongRows) Next() (*domain.SongImportRow, error) {
+	record, err := c.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return &domain.SongImportRow{
+				Line: int64(parseErr.StartLine),
+				Err:  fmt.Errorf("%w: %w", domain.ErrInvalidRequest, parseErr.Err),
+			}, nil
+		}
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("%w: failed to read CSV: %w", domain.ErrInvalidRequest, err)
+	}
+
+	line, _ := c.reader.FieldPos(0)
+
+	song := &models.Song{}
+	for i, value := range record {
+		if err := setCSVSongField(song, c.columns[i], value); err != nil {
+			return &domain.SongImportRow{
+				Line: int64(line),
+				Err:  fmt.Errorf("%w: %s: %w", domain.ErrValidation, c.columns[i], err),
+			}, nil
+		}
+	}
+
+	return importRow(int64(line), song, c.formats), nil
+}
+
+func setCSVSongField(song *models.Song, column string, value string) error {
+	switch column {
+	case "groupName":
+		song.GroupName = value
+	case "songTitle":
+		song.SongTitle = value
+	case "songText":
+		song.SongText = value
+	case "link":
+		song.Link = value
+	case "releaseDate":
+		if value == "" {
+			return nil
+		}
+		date, err := models.ParseDate(value)
+		if err != nil {
+			return err
+		}
+		song.ReleaseDate = &models.Date{Time: date}
+	case "albumId", "discNumber", "trackNumber":
+		if value == "" {
+			return nil
+		}
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		switch column {
+		case "albumId":
+			song.AlbumId = &number
+		case "discNumber":
+			song.DiscNumber = &number
+		default:
+			song.TrackNumber = &number
+		}
+	}
+
+	return nil
+}
+
+// ndjsonSongRows reads songs from JSON Lines, one song object per line.
+// Blank lines are skipped.
+type ndjsonSongRows struct {
+	reader  *bufio.Reader
+	line    int64
+	formats strfmt.Registry
+}
+
+func newNDJSONSongRows(r io.Reader, formats strfmt.Registry) *ndjsonSongRows {
+	return &ndjsonSongRows{reader: bufio.NewReader(r), formats: formats}
+}
+
+func (n *ndjsonSongRows) Next() (*domain.SongImportRow, error) {
+	for {
+		data, err := n.reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: failed to read JSON Lines: %w", domain.ErrInvalidRequest, err)
+		}
+		if len(data) == 0 && errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		n.line++
+
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			continue
+		}
+
+		var song models.Song
+		if err := json.Unmarshal(data, &song); err != nil {
+			return &domain.SongImportRow{
+				Line: n.line,
+				Err:  fmt.Errorf("%w: invalid JSON: %w", domain.ErrInvalidRequest, err),
+			}, nil
+		}
+
+		return importRow(n.line, &song, n.formats), nil
+	}
+}
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/handler/writes"
+	"github.com/salmon822/test_task/models"
+	"golang.org/x/text/language"
+)
+
+// anyLanguage is what the "*" range of Accept-Language is parsed to.
+var anyLanguage = language.MustParse("mul")
+
+// parseLanguageTag validates a BCP 47 language tag and returns its canonical
+// form, e.g. "en-US" for "en-us".
+func parseLanguageTag(value string) (string, error) {
+	tag, err := language.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid language tag %q: %w", value, err)
+	}
+	return tag.String(), nil
+}
+
+// lyricsLanguages returns the languages the client wants the lyrics in, most
+// preferred first: the lang parameter when it is set, otherwise the languages
+// of the Accept-Language header. A malformed header is ignored.
+func lyricsLanguages(r *http.Request) ([]string, error) {
+	if value := r.URL.Query().Get("lang"); value != "" {
+		lang, err := parseLanguageTag(value)
+		if err != nil {
+			return nil, err
+		}
+		return []string{lang}, nil
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if err != nil {
+		return nil, nil
+	}
+
+	var languages []string
+	for _, tag := range tags {
+		if tag != language.Und && tag != anyLanguage {
+			languages = append(languages, tag.String())
+		}
+	}
+	return languages, nil
+}
+
+func (h *handler) parsePathLanguageParam(r *http.Request, dest *string) error {
+	lang, err := parseLanguageTag(mux.Vars(r)["lang"])
+	if err != nil {
+		return err
+	}
+
+	*dest = lang
+	return nil
+}
+
+func (h *handler) getSongTranslations(w http.ResponseWriter, r *http.Request) {
+	var id int64
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.songLyrics.GetSongTranslations(ctx, id)
+	if err != nil {
+		h.logger.Errorf("Failed to get translations for song ID %d: %v", id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to get song translations: %w", err))
+		return
+	}
+
+	h.logger.Infof("Retrieved translations successfully for song ID: %d", id)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.MapSlice(res, domain.SongLyricsDomain2Models))
+}
+
+func (h *handler) getSongTranslation(w http.ResponseWriter, r *http.Request) {
+	var (
+		id   int64
+		lang string
+	)
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+	if err := h.parsePathLanguageParam(r, &lang); err != nil {
+		h.logger.Errorf("Failed to parse language from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.songLyrics.GetSongTranslation(ctx, id, lang)
+	if err != nil {
+		h.logger.Errorf("Failed to get %s translation for song ID %d: %v", lang, id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to get song translation: %w", err))
+		return
+	}
+	if res == nil {
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("%w: song %d has no %s translation", domain.ErrNotFound, id, lang))
+		return
+	}
+
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.SongLyricsDomain2Models(res))
+}
+
+func (h *handler) putSongTranslation(w http.ResponseWriter, r *http.Request) {
+	var (
+		id   int64
+		lang string
+	)
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+	if err := h.parsePathLanguageParam(r, &lang); err != nil {
+		h.logger.Errorf("Failed to parse language from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	var req models.SongLyricsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Errorf("Failed to decode request body: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to decode request body: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	if err := req.Validate(h.validationFormats); err != nil {
+		h.logger.Errorf("Validation failed: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("%w: %w", domain.ErrValidation, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.songLyrics.PutSongTranslation(ctx, &domain.SongLyrics{
+		SongID:   id,
+		Language: lang,
+		SongText: req.SongText,
+	})
+	if err != nil {
+		h.logger.Errorf("Failed to save %s translation for song ID %d: %v", lang, id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to save song translation: %w", err))
+		return
+	}
+
+	h.logger.Infof("Saved %s translation successfully for song ID: %d", lang, id)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.SongLyricsDomain2Models(res))
+}
+
+func (h *handler) deleteSongTranslation(w http.ResponseWriter, r *http.Request) {
+	var (
+		id   int64
+		lang string
+	)
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+	if err := h.parsePathLanguageParam(r, &lang); err != nil {
+		h.logger.Errorf("Failed to parse language from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.songLyrics.DeleteSongTranslation(ctx, id, lang)
+	if err != nil {
+		h.logger.Errorf("Failed to delete %s translation from song ID %d: %v", lang, id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to delete song translation: %w", err))
+		return
+	}
+	if res == nil {
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("%w: song %d has no %s translation", domain.ErrNotFound, id, lang))
+		return
+	}
+
+	h.logger.Infof("Deleted %s translation successfully from song ID: %d", lang, id)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, successResponse(true))
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/handler/writes"
+	"github.com/salmon822/test_task/internal/pkg/jsonpatch"
+	"github.com/salmon822/test_task/models"
+)
+
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+	// maxPatchSize bounds the size of a song patch.
+	maxPatchSize = 1 << 20
+)
+
+// acceptPatch lists the patch formats of songs, as sent in Accept-Patch.
+var acceptPatch = "application/json, " + mergePatchContentType + ", " + jsonPatchContentType
+
+// patchSong changes the song with a patch in the format of the body:
+// application/json bodies hold a SongUpdateRequest, merge patches (RFC 7396)
+// hold the song fields to set, null clearing them, and JSON Patches (RFC 6902)
+// hold operations on the song as it is returned.
+func (h *handler) patchSong(w http.ResponseWriter, r *http.Request) {
+	var id int64
+	defer r.Body.Close()
+
+	w.Header().Set("Accept-Patch", acceptPatch)
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		err = fmt.Errorf("%w: Content-Type must be one of %s", domain.ErrUnsupportedMediaType, acceptPatch)
+		h.logger.Errorf("Failed to parse Content-Type: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, err)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPatchSize))
+	if err != nil {
+		h.logger.Errorf("Failed to read request body: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to read request body: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	var patcher domain.SongPatcher
+	switch mediaType {
+	case "application/json":
+		var req models.SongPatchRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			h.logger.Errorf("Failed to decode request body: %v", err)
+			writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to decode request body: %w: %w", domain.ErrInvalidRequest, err))
+			return
+		}
+		if err := req.Validate(h.validationFormats); err != nil {
+			h.logger.Errorf("Validation failed: %v", err)
+			writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("%w: %w", domain.ErrValidation, err))
+			return
+		}
+		patcher = fixedSongPatcher(domain.SongPatchModels2Domain(req.Song))
+	case mergePatchContentType:
+		var patch models.SongPatch
+		if err := json.Unmarshal(body, &patch); err != nil {
+			h.logger.Errorf("Failed to decode merge patch: %v", err)
+			writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to decode merge patch: %w: %w", domain.ErrInvalidRequest, err))
+			return
+		}
+		if err := patch.Validate(h.validationFormats); err != nil {
+			h.logger.Errorf("Validation failed: %v", err)
+			writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("%w: %w", domain.ErrValidation, err))
+			return
+		}
+		patcher = fixedSongPatcher(domain.SongPatchModels2Domain(&patch))
+	case jsonPatchContentType:
+		patch, err := jsonpatch.Decode(body)
+		if err != nil {
+			h.logger.Errorf("Failed to decode JSON Patch: %v", err)
+			writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+			return
+		}
+		patcher = h.jsonSongPatcher(patch)
+	default:
+		err := fmt.Errorf("%w: Content-Type %q is not one of %s", domain.ErrUnsupportedMediaType, mediaType, acceptPatch)
+		h.logger.Errorf("Failed to patch song: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.songs.PatchSong(ctx, id, patcher, parseIfMatch(r))
+	if err != nil {
+		h.logger.Errorf("Failed to patch song with ID %d: %v", id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to patch song: %w", err))
+		return
+	}
+
+	h.logger.Infof("Song patched successfully with ID: %d", id)
+	setSongETag(w, res)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.SongDomain2Models(res))
+}
+
+// fixedSongPatcher returns the patch whatever the state of the song.
+func fixedSongPatcher(patch *domain.SongPatch) domain.SongPatcher {
+	return func(*domain.Song) (*domain.SongPatch, error) {
+		return patch, nil
+	}
+}
+
+// jsonSongPatcher applies the JSON Patch to the song as the API returns it.
+// The members it changes make a merge patch, so that the result is checked
+// like a merge patch would be.
+func (h *handler) jsonSongPatcher(patch jsonpatch.Patch) domain.SongPatcher {
+	return func(song *domain.Song) (*domain.SongPatch, error) {
+		current, err := json.Marshal(domain.SongDomain2Models(song))
+		if err != nil {
+			return nil, err
+		}
+
+		patched, err := patch.Apply(current)
+		if err != nil {
+			switch {
+			case errors.Is(err, jsonpatch.ErrTestFailed):
+				return nil, fmt.Errorf("%w: %w", domain.ErrConflict, err)
+			case errors.Is(err, jsonpatch.ErrInvalid):
+				return nil, fmt.Errorf("%w: %w", domain.ErrInvalidRequest, err)
+			}
+			return nil, fmt.Errorf("%w: %w", domain.ErrValidation, err)
+		}
+
+		changes, err := songChanges(current, patched)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrValidation, err)
+		}
+
+		var songPatch models.SongPatch
+		if err := json.Unmarshal(changes, &songPatch); err != nil {
+			return nil, fmt.Errorf("%w: the patched song is not valid: %w", domain.ErrValidation, err)
+		}
+		if err := songPatch.Validate(h.validationFormats); err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrValidation, err)
+		}
+
+		return domain.SongPatchModels2Domain(&songPatch), nil
+	}
+}
+
+// songChanges returns the merge patch turning the current song into the
+// patched one: the members that changed, with null for the removed ones.
+func songChanges(current, patched []byte) ([]byte, error) {
+	var before, after map[string]json.RawMessage
+	if err := json.Unmarshal(current, &before); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(patched, &after); err != nil || after == nil {
+		return nil, fmt.Errorf("the patched song must be an object")
+	}
+
+	changes := make(map[string]json.RawMessage)
+	for field, value := range after {
+		if old, ok := before[field]; !ok || !jsonEqual(old, value) {
+			changes[field] = value
+		}
+	}
+	for field := range before {
+		if _, ok := after[field]; !ok {
+			changes[field] = json.RawMessage("null")
+		}
+	}
+
+	return json.Marshal(changes)
+}
+
+func jsonEqual(a, b json.RawMessage) bool {
+	var x, y bytes.Buffer
+	if json.Compact(&x, a) != nil || json.Compact(&y, b) != nil {
+		return false
+	}
+	return bytes.Equal(x.Bytes(), y.Bytes())
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/handler/writes"
+)
+
+func (h *handler) getSongRevisions(w http.ResponseWriter, r *http.Request) {
+	var id int64
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.songs.GetSongRevisions(ctx, id)
+	if err != nil {
+		h.logger.Errorf("Failed to get revisions for song ID %d: %v", id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to get song revisions: %w", err))
+		return
+	}
+
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.MapSlice(res, domain.SongRevisionDomain2Models))
+}
+
+func (h *handler) restoreSongRevision(w http.ResponseWriter, r *http.Request) {
+	var id, revision int64
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+	if err := h.parsePathInt64Param(r, "rev", &revision); err != nil {
+		h.logger.Errorf("Failed to parse revision from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.songs.RestoreSongRevision(ctx, id, revision)
+	if err != nil {
+		h.logger.Errorf("Failed to restore song ID %d to revision %d: %v", id, revision, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to restore song revision: %w", err))
+		return
+	}
+	if res == nil {
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("%w: song %d has no revision %d", domain.ErrNotFound, id, revision))
+		return
+	}
+
+	h.logger.Infof("Song with ID %d restored to revision %d", id, revision)
+	setSongETag(w, res)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.SongDomain2Models(res))
+}
@@ -5,6 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/salmon822/test_task/internal/domain"
 	"github.com/salmon822/test_task/internal/handler/writes"
@@ -20,27 +25,29 @@ func (h *handler) createSong(w http.ResponseWriter, r *http.Request) {
 	var req models.SongCreateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.logger.Errorf("Failed to decode request body: %v", err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("failed to decode request body: %w", err))
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to decode request body: %w: %w", domain.ErrInvalidRequest, err))
 		return
 	}
 	h.logger.Infof("SongCreateRequest decoded successfully")
 
 	if err := req.Validate(h.validationFormats); err != nil {
 		h.logger.Errorf("Validation failed: %v", err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("validation failed: %w", err))
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("%w: %w", domain.ErrValidation, err))
 		return
 	}
 
 	res, err := h.songs.CreateSong(ctx, domain.SongModels2Domain(req.Song))
 	if err != nil {
 		h.logger.Errorf("Failed to create song: %v", err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("failed to create song: %w", err))
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to create song: %w", err))
 		return
 	}
 
 	h.logger.Infof("Song created successfully with ID: %d", res.ID)
 	song := domain.SongDomain2Models(res)
 
+	setSongETag(w, res)
+
 	writes.WriteResponseWithErrorLog(w, http.StatusOK, song)
 }
 
@@ -50,7 +57,7 @@ func (h *handler) deleteSong(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
 		h.logger.Errorf("Failed to parse ID from path: %v", err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("parse failed: %w", err))
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
 		return
 	}
 
@@ -59,7 +66,7 @@ func (h *handler) deleteSong(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.songs.DeleteSong(ctx, id); err != nil {
 		h.logger.Errorf("Failed to delete song with ID %d: %v", id, err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("failed to delete song: %w", err))
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to delete song: %w", err))
 		return
 	}
 
@@ -67,42 +74,122 @@ func (h *handler) deleteSong(w http.ResponseWriter, r *http.Request) {
 	writes.WriteResponseWithErrorLog(w, http.StatusOK, successResponse(true))
 }
 
+func (h *handler) restoreSong(w http.ResponseWriter, r *http.Request) {
+	var id int64
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.songs.RestoreSong(ctx, id)
+	if err != nil {
+		h.logger.Errorf("Failed to restore song with ID %d: %v", id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to restore song: %w", err))
+		return
+	}
+	if res == nil {
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("%w: song %d is not in the trash", domain.ErrNotFound, id))
+		return
+	}
+
+	h.logger.Infof("Song restored successfully with ID: %d", id)
+	setSongETag(w, res)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.SongDomain2Models(res))
+}
+
+// updateSong serves the deprecated PATCH /songs/{id}/update. Its semantics are
+// frozen until the route is removed: empty fields are left unchanged, so link
+// and songText cannot be cleared through it. Use patchSong for that.
 func (h *handler) updateSong(w http.ResponseWriter, r *http.Request) {
 	var id int64
 	defer r.Body.Close()
 
 	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
 		h.logger.Errorf("Failed to parse ID from path: %v", err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("parse failed: %w", err))
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
 		return
 	}
 
 	var req models.SongUpdateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.logger.Errorf("Failed to decode request body: %v", err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("failed to decode request body: %w", err))
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to decode request body: %w: %w", domain.ErrInvalidRequest, err))
 		return
 	}
 
 	if err := req.Validate(h.validationFormats); err != nil {
 		h.logger.Errorf("Validation failed: %v", err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("validation failed: %w", err))
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("%w: %w", domain.ErrValidation, err))
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
 	defer cancel()
 
-	songToUpdate, err := h.songs.UpdateSong(ctx, id, domain.SongModels2Domain(req.Song))
+	songToUpdate, err := h.songs.UpdateSong(ctx, id, domain.SongModels2Domain(req.Song), parseIfMatch(r))
 	if err != nil {
 		h.logger.Errorf("Failed to update song with ID %d: %v", id, err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("failed to update song: %w", err))
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to update song: %w", err))
 		return
 	}
 
 	h.logger.Infof("Song updated successfully with ID: %d", id)
 	song := domain.SongDomain2Models(songToUpdate)
 
+	setSongETag(w, songToUpdate)
+
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, song)
+}
+
+// getSong returns the song, reduced to the fields listed in fields when set.
+// The lyrics are not read unless they are selected.
+func (h *handler) getSong(w http.ResponseWriter, r *http.Request) {
+	var id int64
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	fields, err := parseSongFields(r.URL.Query().Get("fields"))
+	if err != nil {
+		h.logger.Errorf("Failed to parse fields: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	withText := fields == nil || slices.Contains(fields, domain.SongFieldSongText)
+	res, err := h.songs.GetSong(ctx, id, withText)
+	if err != nil {
+		h.logger.Errorf("Failed to get song with ID %d: %v", id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to get song: %w", err))
+		return
+	}
+
+	song, err := selectSongFields(domain.SongDomain2Models(res), fields)
+	if err != nil {
+		h.logger.Errorf("Failed to select song fields: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to select song fields: %w", err))
+		return
+	}
+
+	h.logger.Infof("Retrieved song successfully with ID: %d", id)
+	if fields == nil {
+		setSongETag(w, res)
+	} else {
+		setSongWeakETag(w, res)
+	}
 	writes.WriteResponseWithErrorLog(w, http.StatusOK, song)
 }
 
@@ -112,85 +199,353 @@ func (h *handler) getSongText(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
 		h.logger.Errorf("Failed to parse ID from path: %v", err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("parse failed: %w", err))
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
 		return
 	}
 
-	page, err := h.parseQueryInt64Param(r, "page", 1)
+	page, pageSize, err := h.parsePageParams(r, 5)
 	if err != nil {
-		h.logger.Errorf("Failed to parse page: %v", err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("parse failed: %w", err))
+		h.logger.Errorf("Failed to parse pagination: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
 		return
 	}
-	pageSize, err = h.parseQueryInt64Param(r, "pageSize", 5)
+
+	unit := domain.LyricsUnitLine
+	if value := r.URL.Query().Get("unit"); value != "" {
+		if value != domain.LyricsUnitLine && value != domain.LyricsUnitStanza {
+			err := fmt.Errorf("unit must be %q or %q", models.SongWithVersesUnitLine, models.SongWithVersesUnitStanza)
+			h.logger.Errorf("Failed to parse unit: %v", err)
+			writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+			return
+		}
+		unit = value
+	}
+
+	languages, err := lyricsLanguages(r)
 	if err != nil {
-		h.logger.Errorf("Failed to parse pageSize: %v", err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("parse failed: %w", err))
+		h.logger.Errorf("Failed to parse lang: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
 	defer cancel()
 
-	res, err := h.songs.GetSongTextByID(ctx, id, page, pageSize)
+	res, err := h.songs.GetSongTextByID(ctx, id, &domain.LyricsPagination{
+		Languages: languages,
+		Unit:      unit,
+		Page:      page,
+		PageSize:  pageSize,
+	})
 	if err != nil {
 		h.logger.Errorf("Failed to get song text for ID %d: %v", id, err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("failed to get song text: %w", err))
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to get song text: %w", err))
 		return
 	}
 
 	h.logger.Infof("Retrieved song text successfully for ID: %d", id)
-	writes.WriteResponseWithErrorLog(w, http.StatusOK, res)
+	w.Header().Set("Vary", "Accept-Language")
+	setSongWeakETag(w, &res.Song)
+	if res.Language != "" {
+		w.Header().Set("Content-Language", res.Language)
+	}
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.SongWithVersesDomain2Models(res))
 }
 
 func (h *handler) getFilteredSongs(w http.ResponseWriter, r *http.Request) {
-	var filters domain.SongFilters
 	defer r.Body.Close()
 
-	if err := h.parseQueryStringParam(r, "groupName", &filters.GroupName); err != nil {
-		h.logger.Errorf("Failed to parse groupName: %v", err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("parse failed: %w", err))
+	filters, err := h.parseSongFilters(r)
+	if err != nil {
+		h.logger.Errorf("Failed to parse filters: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
 		return
 	}
-	if err := h.parseQueryStringParam(r, "songTitle", &filters.SongTitle); err != nil {
-		h.logger.Errorf("Failed to parse songTitle: %v", err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("parse failed: %w", err))
+
+	pagination, err := h.parseSongPagination(r)
+	if err != nil {
+		h.logger.Errorf("Failed to parse pagination: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
 		return
 	}
-	releaseDateParam := r.URL.Query().Get("releaseDate")
-	if releaseDateParam != "" {
-		releaseDateValue, err := h.parseQueryInt64Param(r, "releaseDate", 0)
-		if err != nil {
-			h.logger.Errorf("Failed to parse releaseDate: %v", err)
-			writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("parse failed: %w", err))
-			return
+	if pagination.Sort, err = parseSongSort(r.URL.Query().Get("sort")); err != nil {
+		h.logger.Errorf("Failed to parse sort: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.songs.GetFilteredSongs(ctx, filters, pagination)
+	if err != nil {
+		h.logger.Errorf("Failed to get filtered songs: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to get filtered songs: %w", err))
+		return
+	}
+
+	h.logger.Infof("Retrieved filtered songs successfully")
+	response := domain.SongListDomain2Models(res)
+	if link := songListLinkHeader(r.URL, response); link != "" {
+		w.Header().Add("Link", link)
+	}
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, response)
+}
+
+// parseSongFilters reads the song filters shared by the song listing and the
+// export from the query.
+func (h *handler) parseSongFilters(r *http.Request) (*domain.SongFilters, error) {
+	var filters domain.SongFilters
+
+	if err := h.parseQueryStringParam(r, "groupName", &filters.GroupName); err != nil {
+		return nil, fmt.Errorf("groupName: %w", err)
+	}
+	if err := h.parseQueryStringParam(r, "songTitle", &filters.SongTitle); err != nil {
+		return nil, fmt.Errorf("songTitle: %w", err)
+	}
+	if err := h.parseQueryOptionalInt64Param(r, "artistId", &filters.ArtistID); err != nil {
+		return nil, fmt.Errorf("artistId: %w", err)
+	}
+	if err := h.parseQueryOptionalInt64Param(r, "albumId", &filters.AlbumID); err != nil {
+		return nil, fmt.Errorf("albumId: %w", err)
+	}
+	for param, dest := range map[string]**time.Time{
+		"releaseDate":  &filters.ReleaseDate,
+		"releasedFrom": &filters.ReleasedFrom,
+		"releasedTo":   &filters.ReleasedTo,
+	} {
+		if err := h.parseQueryDateParam(r, param, dest); err != nil {
+			return nil, err
+		}
+	}
+	if filters.ReleasedFrom != nil && filters.ReleasedTo != nil && filters.ReleasedFrom.After(*filters.ReleasedTo) {
+		return nil, fmt.Errorf("releasedFrom must not be after releasedTo")
+	}
+
+	if err := h.parseQueryStringListParam(r, "tags", &filters.Tags); err != nil {
+		return nil, fmt.Errorf("tags: %w", err)
+	}
+	filters.TagsMatch = r.URL.Query().Get("tagsMatch")
+	if filters.TagsMatch == "" {
+		filters.TagsMatch = domain.TagsMatchAny
+	}
+	if filters.TagsMatch != domain.TagsMatchAny && filters.TagsMatch != domain.TagsMatchAll {
+		return nil, fmt.Errorf("tagsMatch must be %q or %q", domain.TagsMatchAny, domain.TagsMatchAll)
+	}
+	if err := h.parseQueryStringParam(r, "genre", &filters.Genre); err != nil {
+		return nil, fmt.Errorf("genre: %w", err)
+	}
+	if err := h.parseQueryBoolParam(r, "fuzzy", &filters.Fuzzy); err != nil {
+		return nil, fmt.Errorf("fuzzy: %w", err)
+	}
+	if err := h.parseQueryBoolParam(r, "includeDeleted", &filters.IncludeDeleted); err != nil {
+		return nil, fmt.Errorf("includeDeleted: %w", err)
+	}
+
+	return &filters, nil
+}
+
+// parseSongSort parses a comma-separated list of sort fields, each optionally
+// prefixed with "-" for descending order, e.g. "-releaseDate,groupName".
+func parseSongSort(param string) ([]domain.SongSort, error) {
+	if param == "" {
+		return nil, nil
+	}
+
+	var (
+		sort []domain.SongSort
+		seen = make(map[string]bool)
+	)
+	for _, value := range strings.Split(param, ",") {
+		value = strings.TrimSpace(value)
+
+		field := domain.SongSort{Field: strings.TrimPrefix(value, "-")}
+		field.Desc = field.Field != value
+		if !slices.Contains(domain.SongSortFields, field.Field) {
+			return nil, fmt.Errorf("invalid sort field %q, allowed fields are %s",
+				field.Field, strings.Join(domain.SongSortFields, ", "))
+		}
+		if seen[field.Field] {
+			return nil, fmt.Errorf("sort field %q is used more than once", field.Field)
+		}
+		seen[field.Field] = true
+
+		sort = append(sort, field)
+	}
+
+	return sort, nil
+}
+
+// parseSongFields parses a comma-separated sparse fieldset, e.g.
+// "id,groupName,songTitle". It returns nil when every field is selected.
+func parseSongFields(param string) ([]string, error) {
+	if param == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	for _, field := range strings.Split(param, ",") {
+		field = strings.TrimSpace(field)
+		if !slices.Contains(domain.SongFields, field) {
+			return nil, fmt.Errorf("invalid field %q, allowed fields are %s",
+				field, strings.Join(domain.SongFields, ", "))
+		}
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields, nil
+}
+
+// selectSongFields returns the members of the song listed in fields, the
+// whole song when fields is nil. Selected members are present even when
+// empty.
+func selectSongFields(song *models.Song, fields []string) (any, error) {
+	if fields == nil {
+		return song, nil
+	}
+
+	data, err := json.Marshal(song)
+	if err != nil {
+		return nil, err
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if value, ok := members[field]; ok {
+			res[field] = value
+		} else {
+			res[field] = json.RawMessage("null")
+		}
+	}
+
+	return res, nil
+}
+
+// songListLinkHeader builds the RFC 8288 Link header pointing to the pages
+// around the listed one, by page number or by cursor depending on how the
+// page was requested.
+func songListLinkHeader(u *url.URL, list *models.SongListResponse) string {
+	link := func(rel string, set map[string]string) string {
+		query := u.Query()
+		for _, key := range []string{"page", "after", "before"} {
+			query.Del(key)
+		}
+		for key, value := range set {
+			query.Set(key, value)
+		}
+		target := url.URL{Path: u.Path, RawQuery: query.Encode()}
+		return fmt.Sprintf("<%s>; rel=\"%s\"", target.String(), rel)
+	}
+
+	var links []string
+	if list.Page != nil {
+		page := *list.Page
+		if page < list.TotalPages {
+			links = append(links, link("next", map[string]string{"page": strconv.FormatInt(page+1, 10)}))
+		}
+		if page > 1 {
+			links = append(links, link("prev", map[string]string{"page": strconv.FormatInt(page-1, 10)}))
+		}
+		links = append(links, link("first", map[string]string{"page": "1"}))
+		if list.TotalPages > 0 {
+			links = append(links, link("last", map[string]string{"page": strconv.FormatInt(list.TotalPages, 10)}))
+		}
+	} else {
+		if list.NextCursor != nil {
+			links = append(links, link("next", map[string]string{"after": *list.NextCursor}))
+		}
+		if list.PrevCursor != nil {
+			links = append(links, link("prev", map[string]string{"before": *list.PrevCursor}))
 		}
-		filters.ReleaseDate = &releaseDateValue
 	}
 
-	page, err := h.parseQueryInt64Param(r, "page", 1)
+	return strings.Join(links, ", ")
+}
+
+// parseSongPagination reads offset (page, pageSize) and keyset (after, before)
+// pagination of a song listing.
+func (h *handler) parseSongPagination(r *http.Request) (*domain.SongPagination, error) {
+	var (
+		pagination domain.SongPagination
+		err        error
+	)
+
+	pagination.Page, pagination.PageSize, err = h.parsePageParams(r, 5)
 	if err != nil {
-		h.logger.Errorf("Failed to parse page: %v", err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("parse failed: %w", err))
+		return nil, err
+	}
+
+	after, before := r.URL.Query().Get("after"), r.URL.Query().Get("before")
+	if after != "" && before != "" {
+		return nil, fmt.Errorf("after and before cannot be used together")
+	}
+	if after != "" {
+		if pagination.After, err = domain.DecodeSongCursor(after); err != nil {
+			return nil, err
+		}
+	}
+	if before != "" {
+		if pagination.Before, err = domain.DecodeSongCursor(before); err != nil {
+			return nil, err
+		}
+	}
+
+	return &pagination, nil
+}
+
+var searchLanguages = map[string]string{
+	string(models.En): domain.SearchLanguageEnglish,
+	string(models.Ru): domain.SearchLanguageRussian,
+}
+
+func (h *handler) searchSongs(w http.ResponseWriter, r *http.Request) {
+	var search domain.SongSearch
+	defer r.Body.Close()
+
+	search.Query = strings.TrimSpace(r.URL.Query().Get("q"))
+	if search.Query == "" {
+		err := fmt.Errorf("q must not be empty")
+		h.logger.Errorf("Failed to parse q: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
 		return
 	}
-	pageSize, err := h.parseQueryInt64Param(r, "pageSize", 5)
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		language, ok := searchLanguages[lang]
+		if !ok {
+			err := fmt.Errorf("lang must be %q or %q", models.En, models.Ru)
+			h.logger.Errorf("Failed to parse lang: %v", err)
+			writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+			return
+		}
+		search.Language = language
+	}
+
+	pagination, err := h.parseSongPagination(r)
+	if err == nil && (pagination.After != nil || pagination.Before != nil) {
+		err = fmt.Errorf("search results are paged by page, after and before are not supported")
+	}
 	if err != nil {
-		h.logger.Errorf("Failed to parse pageSize: %v", err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("parse failed: %w", err))
+		h.logger.Errorf("Failed to parse pagination: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
 	defer cancel()
 
-	res, err := h.songs.GetFilteredSongs(ctx, &filters, page, pageSize)
+	res, err := h.songs.SearchSongs(ctx, &search, pagination.Page, pagination.PageSize)
 	if err != nil {
-		h.logger.Errorf("Failed to get filtered songs: %v", err)
-		writes.WriteErrorResponseWithErrorLog(w, fmt.Errorf("failed to get filtered songs: %w", err))
+		h.logger.Errorf("Failed to search songs: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to search songs: %w", err))
 		return
 	}
 
-	h.logger.Infof("Retrieved filtered songs successfully")
-	writes.WriteResponseWithErrorLog(w, http.StatusOK, res)
+	h.logger.Infof("Searched songs successfully")
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.MapSlice(res, domain.SongSearchResultDomain2Models))
 }
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/handler/writes"
+)
+
+const (
+	lrcContentType = "application/x-lrc; charset=utf-8"
+	// maxLRCSize bounds the size of an uploaded LRC file.
+	maxLRCSize = 1 << 20
+)
+
+func (h *handler) getSyncedLyrics(w http.ResponseWriter, r *http.Request) {
+	var id int64
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.syncedLyrics.GetSyncedLyrics(ctx, id)
+	if err != nil {
+		h.logger.Errorf("Failed to get synced lyrics for song ID %d: %v", id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to get synced lyrics: %w", err))
+		return
+	}
+	if len(res.Lines) == 0 {
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("%w: song %d has no synced lyrics", domain.ErrNotFound, id))
+		return
+	}
+
+	h.logger.Infof("Retrieved synced lyrics successfully for song ID: %d", id)
+	writes.WriteTextResponseWithErrorLog(w, http.StatusOK, lrcContentType, domain.FormatLRC(res))
+}
+
+func (h *handler) replaceSyncedLyrics(w http.ResponseWriter, r *http.Request) {
+	var id int64
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxLRCSize))
+	if err != nil {
+		h.logger.Errorf("Failed to read request body: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to read request body: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	lines, err := domain.ParseLRC(string(body))
+	if err != nil {
+		h.logger.Errorf("Failed to parse LRC: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: invalid LRC: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.syncedLyrics.ReplaceSyncedLyrics(ctx, id, lines)
+	if err != nil {
+		h.logger.Errorf("Failed to save synced lyrics for song ID %d: %v", id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to save synced lyrics: %w", err))
+		return
+	}
+
+	h.logger.Infof("Saved synced lyrics successfully for song ID: %d", id)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.SyncedLyricsDomain2Models(res))
+}
+
+func (h *handler) getSyncedLyricsAt(w http.ResponseWriter, r *http.Request) {
+	var id int64
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	seconds, err := strconv.ParseFloat(r.URL.Query().Get("t"), 64)
+	if err != nil || !(seconds >= 0 && seconds <= math.MaxInt64/float64(time.Second)) {
+		err := fmt.Errorf("t must be a non-negative number of seconds, got %q", r.URL.Query().Get("t"))
+		h.logger.Errorf("Failed to parse t: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	position := time.Duration(math.Round(seconds * float64(time.Second)))
+
+	res, err := h.syncedLyrics.GetSyncedLyricsAt(ctx, id, position)
+	if err != nil {
+		h.logger.Errorf("Failed to get synced lyrics line for song ID %d: %v", id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to get synced lyrics line: %w", err))
+		return
+	}
+	if res.Line == nil && res.Next == nil {
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("%w: song %d has no synced lyrics", domain.ErrNotFound, id))
+		return
+	}
+
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.SyncedLyricsPositionDomain2Models(res))
+}
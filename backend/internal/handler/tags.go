@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/handler/writes"
+	"github.com/salmon822/test_task/models"
+)
+
+func (h *handler) getSongTags(w http.ResponseWriter, r *http.Request) {
+	var id int64
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := h.tags.GetSongTags(ctx, id)
+	if err != nil {
+		h.logger.Errorf("Failed to get tags for song ID %d: %v", id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to get song tags: %w", err))
+		return
+	}
+
+	h.logger.Infof("Retrieved tags successfully for song ID: %d", id)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.MapSlice(res, domain.TagDomain2Models))
+}
+
+func (h *handler) addSongTags(w http.ResponseWriter, r *http.Request) {
+	h.assignSongTags(w, r, h.tags.AddSongTags)
+}
+
+func (h *handler) replaceSongTags(w http.ResponseWriter, r *http.Request) {
+	h.assignSongTags(w, r, h.tags.ReplaceSongTags)
+}
+
+func (h *handler) assignSongTags(
+	w http.ResponseWriter,
+	r *http.Request,
+	assign func(ctx context.Context, songID int64, tags []*domain.Tag) ([]*domain.Tag, error),
+) {
+	var id int64
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	var req models.SongTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Errorf("Failed to decode request body: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to decode request body: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	if err := req.Validate(h.validationFormats); err != nil {
+		h.logger.Errorf("Validation failed: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("%w: %w", domain.ErrValidation, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	res, err := assign(ctx, id, domain.MapSlice(req.Tags, domain.TagModels2Domain))
+	if err != nil {
+		h.logger.Errorf("Failed to assign tags to song ID %d: %v", id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to assign song tags: %w", err))
+		return
+	}
+
+	h.logger.Infof("Assigned tags successfully to song ID: %d", id)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, domain.MapSlice(res, domain.TagDomain2Models))
+}
+
+func (h *handler) removeSongTag(w http.ResponseWriter, r *http.Request) {
+	var id, tagID int64
+	defer r.Body.Close()
+
+	if err := h.parsePathInt64Param(r, "id", &id); err != nil {
+		h.logger.Errorf("Failed to parse ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+	if err := h.parsePathInt64Param(r, "tagId", &tagID); err != nil {
+		h.logger.Errorf("Failed to parse tag ID from path: %v", err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("parse failed: %w: %w", domain.ErrInvalidRequest, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.RequestTimeout)
+	defer cancel()
+
+	if err := h.tags.RemoveSongTag(ctx, id, tagID); err != nil {
+		h.logger.Errorf("Failed to remove tag %d from song ID %d: %v", tagID, id, err)
+		writes.WriteErrorResponseWithErrorLog(w, r, fmt.Errorf("failed to remove song tag: %w", err))
+		return
+	}
+
+	h.logger.Infof("Removed tag %d successfully from song ID: %d", tagID, id)
+	writes.WriteResponseWithErrorLog(w, http.StatusOK, successResponse(true))
+}
@@ -5,12 +5,40 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/pkg/requestid"
 	"github.com/salmon822/test_task/models"
 )
 
+// problemContentType is the media type of RFC 7807 problem details.
+const problemContentType = "application/problem+json"
+
+// internalErrorDetail is sent in place of the error of a 500, whose text
+// may tell about the database. The error is logged with the request ID.
+const internalErrorDetail = "An unexpected error occurred, see the server logs for this request ID"
+
+// problemTypes maps the domain errors to the status code, the type and the
+// title of the problem details, the first one found in the error chain wins.
+var problemTypes = []struct {
+	err         error
+	code        int64
+	problemType models.ErrorResponseType
+	title       string
+}{
+	{domain.ErrInvalidRequest, http.StatusBadRequest, models.InvalidRequest, "Invalid request"},
+	{domain.ErrValidation, http.StatusUnprocessableEntity, models.ValidationFailed, "Validation failed"},
+	{domain.ErrNotFound, http.StatusNotFound, models.NotFound, "Not found"},
+	{domain.ErrConflict, http.StatusConflict, models.Conflict, "Conflict"},
+	{domain.ErrPreconditionFailed, http.StatusPreconditionFailed, models.PreconditionFailed, "Precondition failed"},
+	{domain.ErrUnsupportedMediaType, http.StatusUnsupportedMediaType, models.UnsupportedMediaType, "Unsupported media type"},
+	{context.DeadlineExceeded, http.StatusGatewayTimeout, models.Timeout, "Request timed out"},
+	{context.Canceled, http.StatusRequestTimeout, models.RequestCanceled, "Request canceled"},
+}
+
 func WriteResponseWithErrorLog(w http.ResponseWriter, code int64, resp any) {
 	err := WriteResponse(w, code, resp)
 	if err != nil {
@@ -18,10 +46,17 @@ func WriteResponseWithErrorLog(w http.ResponseWriter, code int64, resp any) {
 	}
 }
 
-func WriteErrorResponseWithErrorLog(w http.ResponseWriter, err error) {
-	log.Printf("error occurred: %v", err.Error())
+func WriteTextResponseWithErrorLog(w http.ResponseWriter, code int64, contentType string, body string) {
+	err := WriteTextResponse(w, code, contentType, body)
+	if err != nil {
+		log.Printf("write response failed: %v", err)
+	}
+}
+
+func WriteErrorResponseWithErrorLog(w http.ResponseWriter, r *http.Request, err error) {
+	log.Printf("request %s: error occurred: %v", requestid.FromContext(r.Context()), err.Error())
 
-	writeErr := WriteErrorResponse(w, err)
+	writeErr := WriteErrorResponse(w, r, err)
 	if writeErr != nil {
 		log.Printf("write error response failed: %v", writeErr)
 	}
@@ -40,23 +75,66 @@ func WriteResponse(w http.ResponseWriter, code int64, resp any) error {
 	return nil
 }
 
-func WriteErrorResponse(w http.ResponseWriter, err error) error {
-	var code int64
-	switch {
-	case errors.Is(err, context.DeadlineExceeded):
-		code = http.StatusGatewayTimeout
-	case errors.Is(err, context.Canceled):
-		code = http.StatusRequestTimeout
-	default:
-		code = http.StatusInternalServerError
+// WriteTextResponse writes the body as is, for responses that are not JSON.
+func WriteTextResponse(w http.ResponseWriter, code int64, contentType string, body string) error {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(int(code))
+	if _, err := io.WriteString(w, body); err != nil {
+		return fmt.Errorf("write text resp: %w", err)
+	}
+	return nil
+}
+
+// WriteErrorResponse writes the error as RFC 7807 problem details. Field
+// errors of a failed validation are listed in errors.
+func WriteErrorResponse(w http.ResponseWriter, r *http.Request, err error) error {
+	problem := Problem(r, err)
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(int(problem.Status))
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(problem); err != nil {
+		return fmt.Errorf("encode error resp: %w", err)
+	}
+	return nil
+}
+
+// Problem describes the error of the request as RFC 7807 problem details, for
+// the responses reporting errors of their own, such as those of batches.
+func Problem(r *http.Request, err error) models.ErrorResponse {
+	problem := models.ErrorResponse{
+		Status: http.StatusInternalServerError,
+		Title:  "Internal server error",
+		Type:   models.InternalError,
+	}
+	for _, kind := range problemTypes {
+		if errors.Is(err, kind.err) {
+			problem.Status, problem.Title, problem.Type = kind.code, kind.title, kind.problemType
+			break
+		}
 	}
 
-	message := http.StatusText(int(code))
 	detail := err.Error()
+	if problem.Type == models.InternalError {
+		detail = internalErrorDetail
+	}
+	instance := r.URL.Path
+	problem.Detail, problem.Instance = &detail, &instance
+	if id := requestid.FromContext(r.Context()); id != "" {
+		problem.RequestId = &id
+	}
+
+	if fieldErrors := models.FieldErrors(err); len(fieldErrors) > 0 {
+		fields := make([]models.ErrorResponseField, 0, len(fieldErrors))
+		for _, fieldError := range fieldErrors {
+			fields = append(fields, models.ErrorResponseField{
+				Detail:  fieldError.Err.Error(),
+				Pointer: fieldError.Pointer,
+			})
+		}
+		problem.Errors = &fields
+	}
 
-	return WriteResponse(w, code, models.ErrorResponse{
-		Code:    &code,
-		Detail:  &detail,
-		Message: &message,
-	})
+	return problem
 }
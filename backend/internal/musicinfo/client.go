@@ -0,0 +1,117 @@
+package musicinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/salmon822/test_task/internal/config"
+	"github.com/salmon822/test_task/internal/pkg/logger"
+)
+
+var ErrSongNotFound = errors.New("song not found in music info service")
+
+type SongDetail struct {
+	ReleaseDate string `json:"releaseDate"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+}
+
+type Client interface {
+	GetSongDetail(ctx context.Context, group, song string) (*SongDetail, error)
+}
+
+type client struct {
+	httpClient *http.Client
+	cfg        *config.MusicInfoConfig
+	logger     logger.Logger
+}
+
+func NewClient(
+	cfg *config.MusicInfoConfig,
+	logger logger.Logger,
+) Client {
+	return &client{
+		httpClient: &http.Client{
+			Timeout: cfg.Timeout,
+		},
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// GetSongDetail asks the music info service for the song, retrying transient
+// failures up to RetryCount times.
+func (c *client) GetSongDetail(ctx context.Context, group, song string) (*SongDetail, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.cfg.RetryCount; attempt++ {
+		if attempt > 0 {
+			c.logger.Warnf("MusicInfo: retrying request for %q - %q (attempt %d): %v", group, song, attempt, lastErr)
+
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("MusicInfo/GetSongDetail: %w", ctx.Err())
+			case <-time.After(c.retryDelay(attempt)):
+			}
+		}
+
+		detail, retry, err := c.getSongDetail(ctx, group, song)
+		if err == nil {
+			return detail, nil
+		}
+		if !retry {
+			return nil, fmt.Errorf("MusicInfo/GetSongDetail: %w", err)
+		}
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("MusicInfo/GetSongDetail: retries exhausted: %w", lastErr)
+}
+
+// retryDelay returns the delay before the given retry: RetryDelay doubled for
+// every previous retry, so that a struggling service is given more and more
+// time to recover.
+func (c *client) retryDelay(attempt int) time.Duration {
+	return c.cfg.RetryDelay << (attempt - 1)
+}
+
+// getSongDetail performs a single request. The boolean result reports whether
+// the failure is transient and the request is worth retrying.
+func (c *client) getSongDetail(ctx context.Context, group, song string) (*SongDetail, bool, error) {
+	query := url.Values{}
+	query.Set("group", group)
+	query.Set("song", song)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.BaseURL+"/info?"+query.Encode(), nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("build request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, ctx.Err() == nil, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, false, ErrSongNotFound
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError:
+		return nil, true, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	default:
+		return nil, false, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var detail SongDetail
+	if err := json.NewDecoder(resp.Body).Decode(&detail); err != nil {
+		return nil, false, fmt.Errorf("decode response: %w", err)
+	}
+
+	return &detail, false, nil
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/pkg/logger"
+	"github.com/salmon822/test_task/internal/repository/models"
+)
+
+type AlbumsRepository struct {
+	db     sqlx.ExtContext
+	logger logger.Logger
+}
+
+func NewAlbumsRepository(
+	db *sqlx.DB,
+	logger logger.Logger,
+) Albums {
+	return &AlbumsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *AlbumsRepository) WithTX(tx *sqlx.Tx) Albums {
+	return &AlbumsRepository{
+		db:     tx,
+		logger: r.logger,
+	}
+}
+
+func (r *AlbumsRepository) Create(ctx context.Context, album *models.Album) (*models.Album, error) {
+	query := `
+		INSERT INTO albums (id, artist_id, title, release_date, cover_link, created_at, updated_at)
+		VALUES (default, $1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	row := r.db.QueryRowxContext(ctx, query, album.ArtistID, album.Title, album.ReleaseDate,
+		album.CoverLink, album.CreatedAt, album.UpdatedAt)
+	if err := row.Scan(&album.ID); err != nil {
+		return nil, fmt.Errorf("AlbumsRepo/Create: error: %w", classifyError(err))
+	}
+
+	return album, nil
+}
+
+func (r *AlbumsRepository) GetById(ctx context.Context, id int64) (*models.Album, error) {
+	query := `
+		SELECT al.id, al.artist_id, a.name, al.title, al.release_date, al.cover_link, al.created_at, al.updated_at
+		FROM albums al
+		JOIN artists a ON a.id = al.artist_id
+		WHERE al.id = $1
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	var album models.Album
+	row := r.db.QueryRowxContext(ctx, query, id)
+	err := row.Scan(&album.ID, &album.ArtistID, &album.GroupName, &album.Title, &album.ReleaseDate,
+		&album.CoverLink, &album.CreatedAt, &album.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("AlbumsRepo/GetById: error: %w", classifyError(err))
+	}
+
+	return &album, nil
+}
+
+func (r *AlbumsRepository) GetTracks(ctx context.Context, albumID int64) ([]*models.Song, error) {
+	query := `
+		SELECT ` + songColumns + `
+		FROM songs s
+		JOIN artists a ON a.id = s.artist_id
+		WHERE s.album_id = $1 AND s.deleted_at IS NULL
+		ORDER BY s.disc_number NULLS LAST, s.track_number NULLS LAST, s.id
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	rows, err := r.db.QueryContext(ctx, query, albumID)
+	if err != nil {
+		return nil, fmt.Errorf("AlbumsRepo/GetTracks: error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	var songs []*models.Song
+	for rows.Next() {
+		var song models.Song
+		if err := scanSong(rows, &song); err != nil {
+			return nil, fmt.Errorf("AlbumsRepo/GetTracks: error scanning row: %w", err)
+		}
+		songs = append(songs, &song)
+	}
+
+	return songs, nil
+}
+
+// SetTrackPositions moves the album tracks to the given positions. The unique
+// position constraint is deferred, so it must run inside a transaction and
+// positions may be swapped freely until commit. Songs in the trash are not
+// tracks of the album.
+func (r *AlbumsRepository) SetTrackPositions(ctx context.Context, albumID int64, positions []*models.TrackPosition, updatedAt int64) error {
+	if _, err := r.db.ExecContext(ctx, `SET CONSTRAINTS uq_songs_album_track DEFERRED`); err != nil {
+		return fmt.Errorf("AlbumsRepo/SetTrackPositions: error deferring constraint: %w", err)
+	}
+
+	query := `
+		UPDATE songs
+		SET disc_number = $3, track_number = $4, updated_at = $5, version = version + 1
+		WHERE id = $1 AND album_id = $2 AND deleted_at IS NULL
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	for _, position := range positions {
+		res, err := r.db.ExecContext(ctx, query, position.SongID, albumID, position.DiscNumber, position.TrackNumber, updatedAt)
+		if err != nil {
+			return fmt.Errorf("AlbumsRepo/SetTrackPositions: error: %w", classifyError(err))
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("AlbumsRepo/SetTrackPositions: error: %w", classifyError(err))
+		}
+		if affected == 0 {
+			return fmt.Errorf("AlbumsRepo/SetTrackPositions: %w: song %d is not a track of album %d",
+				domain.ErrValidation, position.SongID, albumID)
+		}
+	}
+
+	// Check the positions now rather than at commit, so that a clash is
+	// reported as a conflict.
+	if _, err := r.db.ExecContext(ctx, `SET CONSTRAINTS uq_songs_album_track IMMEDIATE`); err != nil {
+		return fmt.Errorf("AlbumsRepo/SetTrackPositions: error: %w", classifyError(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salmon822/test_task/internal/pkg/logger"
+	"github.com/salmon822/test_task/internal/repository/models"
+)
+
+type ArtistsRepository struct {
+	db     sqlx.ExtContext
+	logger logger.Logger
+}
+
+func NewArtistsRepository(
+	db *sqlx.DB,
+	logger logger.Logger,
+) Artists {
+	return &ArtistsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *ArtistsRepository) WithTX(tx *sqlx.Tx) Artists {
+	return &ArtistsRepository{
+		db:     tx,
+		logger: r.logger,
+	}
+}
+
+func (r *ArtistsRepository) Create(ctx context.Context, artist *models.Artist) (*models.Artist, error) {
+	query := `
+		INSERT INTO artists (id, name, created_at, updated_at)
+		VALUES (default, $1, $2, $3)
+		RETURNING id
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	row := r.db.QueryRowxContext(ctx, query, artist.Name, artist.CreatedAt, artist.UpdatedAt)
+	if err := row.Scan(&artist.ID); err != nil {
+		return nil, fmt.Errorf("ArtistsRepo/Create: error: %w", classifyError(err))
+	}
+
+	return artist, nil
+}
+
+// GetOrCreate returns the artist whose normalized name matches the given one,
+// creating it when there is none yet.
+func (r *ArtistsRepository) GetOrCreate(ctx context.Context, artist *models.Artist) (*models.Artist, error) {
+	query := `
+		INSERT INTO artists (id, name, created_at, updated_at)
+		VALUES (default, $1, $2, $3)
+		ON CONFLICT ((lower(regexp_replace(btrim(name), '\s+', ' ', 'g')))) DO UPDATE SET name = artists.name
+		RETURNING id, name, created_at, updated_at
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	var res models.Artist
+	row := r.db.QueryRowxContext(ctx, query, artist.Name, artist.CreatedAt, artist.UpdatedAt)
+	if err := row.Scan(&res.ID, &res.Name, &res.CreatedAt, &res.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("ArtistsRepo/GetOrCreate: error: %w", classifyError(err))
+	}
+
+	return &res, nil
+}
+
+func (r *ArtistsRepository) GetById(ctx context.Context, id int64) (*models.Artist, error) {
+	query := `
+		SELECT id, name, created_at, updated_at
+		FROM artists
+		WHERE id = $1
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	var artist models.Artist
+	row := r.db.QueryRowxContext(ctx, query, id)
+	if err := row.Scan(&artist.ID, &artist.Name, &artist.CreatedAt, &artist.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("ArtistsRepo/GetById: error: %w", classifyError(err))
+	}
+
+	return &artist, nil
+}
+
+// Update renames the artist and bumps the version of its songs. It must run
+// inside a transaction.
+func (r *ArtistsRepository) Update(ctx context.Context, artist *models.Artist) (*models.Artist, error) {
+	query := `
+		UPDATE artists
+		SET name = $2, updated_at = $3
+		WHERE id = $1
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	_, err := r.db.ExecContext(ctx, query, artist.ID, artist.Name, artist.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("ArtistsRepo/Update: error: %w", classifyError(err))
+	}
+
+	// The group name of the songs is the artist name, so a rename changes
+	// every one of them and their ETags must not match anymore.
+	songsQuery := `
+		UPDATE songs
+		SET updated_at = $2, version = version + 1
+		WHERE artist_id = $1
+	`
+
+	r.logger.Debugf("SQL Query: %s", songsQuery)
+
+	_, err = r.db.ExecContext(ctx, songsQuery, artist.ID, artist.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("ArtistsRepo/Update: error updating songs: %w", classifyError(err))
+	}
+
+	return artist, nil
+}
+
+func (r *ArtistsRepository) Delete(ctx context.Context, id int64) error {
+	query := `
+		DELETE FROM artists
+		WHERE id = $1
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	_, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("ArtistsRepo/Delete: error: %w", classifyError(err))
+	}
+
+	return nil
+}
+
+func (r *ArtistsRepository) GetArtists(ctx context.Context, filters *models.ArtistFilters, page int64, pageSize int64) ([]*models.Artist, error) {
+	query := `
+		SELECT id, name, created_at, updated_at
+		FROM artists
+		WHERE 1=1
+	`
+	args := []interface{}{}
+	argIndex := 1
+
+	if filters.Name != nil {
+		query += fmt.Sprintf(" AND name ILIKE $%d", argIndex)
+		args = append(args, "%"+*filters.Name+"%")
+		argIndex++
+	}
+
+	query += fmt.Sprintf(" ORDER BY id LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, pageSize, (page-1)*pageSize)
+
+	r.logger.Debugf("SQL Query: %s", query)
+	r.logger.Debugf("Query Arguments: %+v", args)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ArtistsRepo/GetArtists: error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	var artists []*models.Artist
+	for rows.Next() {
+		var artist models.Artist
+		if err := rows.Scan(&artist.ID, &artist.Name, &artist.CreatedAt, &artist.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("ArtistsRepo/GetArtists: error scanning row: %w", err)
+		}
+		artists = append(artists, &artist)
+	}
+
+	return artists, nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/salmon822/test_task/internal/domain"
+)
+
+// PostgreSQL error codes of the constraint violations told apart by
+// classifyError.
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+	pgExclusionViolation  = "23P01"
+)
+
+// classifyError marks the errors caused by the data rather than the database
+// with the matching domain error, other errors are returned as is.
+func classifyError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %w", domain.ErrNotFound, err)
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case pgUniqueViolation, pgForeignKeyViolation, pgExclusionViolation:
+		return fmt.Errorf("%w: %w", domain.ErrConflict, err)
+	case pgCheckViolation:
+		return fmt.Errorf("%w: %w", domain.ErrValidation, err)
+	}
+
+	return err
+}
@@ -0,0 +1,20 @@
+package models
+
+import "database/sql"
+
+type Album struct {
+	ID          int64
+	ArtistID    int64
+	GroupName   string
+	Title       string
+	ReleaseDate sql.NullTime
+	CoverLink   string
+	CreatedAt   int64
+	UpdatedAt   int64
+}
+
+type TrackPosition struct {
+	SongID      int64
+	DiscNumber  int64
+	TrackNumber int64
+}
@@ -0,0 +1,12 @@
+package models
+
+type Artist struct {
+	ID        int64
+	Name      string
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+type ArtistFilters struct {
+	Name *string
+}
@@ -1,26 +1,135 @@
 package models
 
+import (
+	"database/sql"
+	"time"
+)
+
 type Song struct {
 	ID          int64
+	ArtistID    int64
 	GroupName   string
 	SongTitle   string
-	ReleaseDate int64
+	ReleaseDate sql.NullTime
 	SongText    string
 	Link        string
 	CreatedAt   int64
 	UpdatedAt   int64
+	// DeletedAt is the time the song was moved to the trash, it is zero for
+	// songs that are not in the trash.
+	DeletedAt int64
+	// Version is bumped by every write to the song.
+	Version int64
+
+	EnrichmentStatus    string
+	EnrichmentAttempts  int64
+	EnrichmentLastError string
+
+	AlbumID     int64
+	DiscNumber  int64
+	TrackNumber int64
+
+	// Stanzas is the parsed song text, it is written along with SongText and
+	// read with GetStanzas.
+	Stanzas []Stanza
+
+	// Score is the trigram similarity to the fuzzy filters, it is only set by
+	// GetFilteredSongs when SongFilters.Fuzzy is on.
+	Score float64
 }
 
-type SongWithVerses struct {
-	Song
-	TotalVerses int64
-	Page        int64
-	PageSize    int64
-	Verses      []string
+type SongEnrichmentFailure struct {
+	ID            int64
+	Status        string
+	Attempts      int64
+	LastError     string
+	NextAttemptAt int64
 }
 
+type Stanza struct {
+	Section string   `json:"section,omitempty"`
+	Label   string   `json:"label,omitempty"`
+	Lines   []string `json:"lines"`
+}
+
+const (
+	TagsMatchAny = "any"
+	TagsMatchAll = "all"
+)
+
 type SongFilters struct {
 	GroupName   *string
+	ArtistID    *int64
+	AlbumID     *int64
 	SongTitle   *string
-	ReleaseDate *int64
+	ReleaseDate *time.Time
+	// ReleasedFrom and ReleasedTo bound the release date, both inclusive.
+	ReleasedFrom *time.Time
+	ReleasedTo   *time.Time
+	Tags         []string
+	TagsMatch    string
+	Genre        *string
+	Fuzzy        bool
+	// IncludeDeleted lists the songs in the trash along with the others.
+	IncludeDeleted bool
+}
+
+const (
+	SongSortID          = "id"
+	SongSortGroupName   = "groupName"
+	SongSortSongTitle   = "songTitle"
+	SongSortReleaseDate = "releaseDate"
+	SongSortCreatedAt   = "createdAt"
+	SongSortUpdatedAt   = "updatedAt"
+	SongSortScore       = "score"
+)
+
+type SongSort struct {
+	Field string
+	Desc  bool
+}
+
+// SongCursor is the position of a song in a listing: the values of the sort
+// keys of the listing followed by the song ID.
+type SongCursor struct {
+	Keys []any
+	ID   int64
+}
+
+// SongPagination selects a page either by offset or, when After or Before is
+// set, by the keyset following or preceding the cursor. Songs are ordered by
+// Sort and then by ID.
+type SongPagination struct {
+	Sort   []SongSort
+	Limit  int64
+	Offset int64
+	After  *SongCursor
+	Before *SongCursor
+}
+
+const (
+	SearchLanguageEnglish = "english"
+	SearchLanguageRussian = "russian"
+)
+
+type SongSearch struct {
+	Query    string
+	Language string
+}
+
+type SongSearchResult struct {
+	Song
+	Rank    float64
+	Snippet string
+}
+
+// SongLyrics is a version of the song text in another language, Language is
+// a BCP 47 tag.
+type SongLyrics struct {
+	SongID    int64
+	Language  string
+	SongText  string
+	Stanzas   []Stanza
+	CreatedAt int64
+	UpdatedAt int64
 }
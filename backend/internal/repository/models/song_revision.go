@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// SongRevision is the state of a song after a change, Revision counts the
+// changes of the song starting at 1.
+type SongRevision struct {
+	ID        int64
+	SongID    int64
+	Revision  int64
+	Action    string
+	Snapshot  SongSnapshot
+	CreatedAt int64
+}
+
+// SongSnapshot holds the fields of a song kept in its revisions.
+type SongSnapshot struct {
+	ArtistID    int64      `json:"artistId"`
+	GroupName   string     `json:"groupName"`
+	SongTitle   string     `json:"songTitle"`
+	ReleaseDate *time.Time `json:"releaseDate,omitempty"`
+	SongText    string     `json:"songText"`
+	Link        string     `json:"link"`
+	AlbumID     int64      `json:"albumId,omitempty"`
+	DiscNumber  int64      `json:"discNumber,omitempty"`
+	TrackNumber int64      `json:"trackNumber,omitempty"`
+	CreatedAt   int64      `json:"createdAt"`
+}
@@ -0,0 +1,10 @@
+package models
+
+// SyncedLine is a line of time-synced lyrics shown from StartMs milliseconds
+// into the song.
+type SyncedLine struct {
+	SongID  int64
+	Number  int64
+	StartMs int64
+	Text    string
+}
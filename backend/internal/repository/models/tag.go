@@ -0,0 +1,7 @@
+package models
+
+type Tag struct {
+	ID   int64
+	Name string
+	Kind string
+}
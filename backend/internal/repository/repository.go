@@ -11,20 +11,93 @@ import (
 
 type Songs interface {
 	Create(ctx context.Context, song *models.Song) (*models.Song, error)
-	Delete(ctx context.Context, id int64) error
+	CreateBatch(ctx context.Context, songs []*models.Song) ([]*models.Song, error)
+	Delete(ctx context.Context, id int64, deletedAt int64) error
+	Restore(ctx context.Context, id int64) (bool, error)
+	Purge(ctx context.Context, deletedBefore int64, limit int64) (int64, error)
 	GetById(ctx context.Context, id int64) (*models.Song, error)
+	GetByIdWithoutText(ctx context.Context, id int64) (*models.Song, error)
+	GetByIdForUpdate(ctx context.Context, id int64) (*models.Song, error)
+	Exists(ctx context.Context, id int64, includeDeleted bool) (bool, error)
 	Update(ctx context.Context, data *models.Song) (*models.Song, error)
-	GetFilteredSongs(ctx context.Context, filters *models.SongFilters, page int64, pageSize int64) ([]*models.Song, error)
+	GetStanzas(ctx context.Context, id int64) ([]models.Stanza, error)
+	GetFilteredSongs(ctx context.Context, filters *models.SongFilters, pagination *models.SongPagination) ([]*models.Song, error)
+	CountFilteredSongs(ctx context.Context, filters *models.SongFilters) (int64, error)
+	ExportSongs(ctx context.Context, filters *models.SongFilters, sort []models.SongSort, batchSize int64, fn func([]*models.Song) error) error
+	SearchSongs(ctx context.Context, search *models.SongSearch, page int64, pageSize int64) ([]*models.SongSearchResult, error)
+	ClaimSongsToEnrich(ctx context.Context, now int64, claimedUntil int64, limit int64) ([]*models.Song, error)
+	SaveEnrichment(ctx context.Context, song *models.Song) (*models.Song, error)
+	SaveEnrichmentFailure(ctx context.Context, failure *models.SongEnrichmentFailure) error
 	WithTX(tx *sqlx.Tx) Songs
 }
 
+type Artists interface {
+	Create(ctx context.Context, artist *models.Artist) (*models.Artist, error)
+	GetOrCreate(ctx context.Context, artist *models.Artist) (*models.Artist, error)
+	GetById(ctx context.Context, id int64) (*models.Artist, error)
+	Update(ctx context.Context, artist *models.Artist) (*models.Artist, error)
+	Delete(ctx context.Context, id int64) error
+	GetArtists(ctx context.Context, filters *models.ArtistFilters, page int64, pageSize int64) ([]*models.Artist, error)
+	WithTX(tx *sqlx.Tx) Artists
+}
+
+type Albums interface {
+	Create(ctx context.Context, album *models.Album) (*models.Album, error)
+	GetById(ctx context.Context, id int64) (*models.Album, error)
+	GetTracks(ctx context.Context, albumID int64) ([]*models.Song, error)
+	SetTrackPositions(ctx context.Context, albumID int64, positions []*models.TrackPosition, updatedAt int64) error
+	WithTX(tx *sqlx.Tx) Albums
+}
+
+type Tags interface {
+	GetOrCreate(ctx context.Context, tag *models.Tag) (*models.Tag, error)
+	GetSongTags(ctx context.Context, songID int64) ([]*models.Tag, error)
+	AddSongTag(ctx context.Context, songID int64, tagID int64) error
+	RemoveSongTag(ctx context.Context, songID int64, tagID int64) error
+	RemoveSongTags(ctx context.Context, songID int64) error
+	WithTX(tx *sqlx.Tx) Tags
+}
+
+type SongRevisions interface {
+	Create(ctx context.Context, revision *models.SongRevision) (*models.SongRevision, error)
+	CreateBatch(ctx context.Context, revisions []*models.SongRevision) error
+	GetBySongID(ctx context.Context, songID int64) ([]*models.SongRevision, error)
+	Get(ctx context.Context, songID int64, revision int64) (*models.SongRevision, error)
+	WithTX(tx *sqlx.Tx) SongRevisions
+}
+
+type SongLyrics interface {
+	Upsert(ctx context.Context, songLyrics *models.SongLyrics) (*models.SongLyrics, error)
+	Get(ctx context.Context, songID int64, language string) (*models.SongLyrics, error)
+	GetBySongID(ctx context.Context, songID int64) ([]*models.SongLyrics, error)
+	GetLanguages(ctx context.Context, songID int64) ([]string, error)
+	Delete(ctx context.Context, songID int64, language string) error
+	WithTX(tx *sqlx.Tx) SongLyrics
+}
+
+type SyncedLyrics interface {
+	Replace(ctx context.Context, songID int64, lines []*models.SyncedLine) error
+	GetBySongID(ctx context.Context, songID int64) ([]*models.SyncedLine, error)
+	GetLinesAround(ctx context.Context, songID int64, position int64) (*models.SyncedLine, *models.SyncedLine, error)
+	WithTX(tx *sqlx.Tx) SyncedLyrics
+}
+
 type Transactions interface {
 	StartTransaction(ctx context.Context) (*sqlx.Tx, error)
+	Savepoint(ctx context.Context, tx *sqlx.Tx, name string) error
+	RollbackToSavepoint(ctx context.Context, tx *sqlx.Tx, name string) error
+	ReleaseSavepoint(ctx context.Context, tx *sqlx.Tx, name string) error
 }
 
 type Repository struct {
 	Transactions
 	Songs
+	Artists
+	Albums
+	Tags
+	SongRevisions
+	SongLyrics
+	SyncedLyrics
 	logger logger.Logger
 }
 
@@ -35,12 +108,24 @@ func NewRepository(
 ) (*Repository, error) {
 	var (
 		songs        = NewSongsRepository(db, logger)
+		artists      = NewArtistsRepository(db, logger)
+		albums       = NewAlbumsRepository(db, logger)
+		tags         = NewTagsRepository(db, logger)
+		revisions    = NewSongRevisionsRepository(db, logger)
+		songLyrics   = NewSongLyricsRepository(db, logger)
+		syncedLyrics = NewSyncedLyricsRepository(db, logger)
 		transactions = NewTransactionsRepo(db)
 	)
 
 	return &Repository{
-		Transactions: transactions,
-		Songs:        songs,
-		logger:       logger,
+		Transactions:  transactions,
+		Songs:         songs,
+		Artists:       artists,
+		Albums:        albums,
+		Tags:          tags,
+		SongRevisions: revisions,
+		SongLyrics:    songLyrics,
+		SyncedLyrics:  syncedLyrics,
+		logger:        logger,
 	}, nil
 }
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salmon822/test_task/internal/pkg/logger"
+	"github.com/salmon822/test_task/internal/repository/models"
+)
+
+type SongLyricsRepository struct {
+	db     sqlx.ExtContext
+	logger logger.Logger
+}
+
+func NewSongLyricsRepository(
+	db *sqlx.DB,
+	logger logger.Logger,
+) SongLyrics {
+	return &SongLyricsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *SongLyricsRepository) WithTX(tx *sqlx.Tx) SongLyrics {
+	return &SongLyricsRepository{
+		db:     tx,
+		logger: r.logger,
+	}
+}
+
+// Upsert stores the lyrics, replacing the text of the song in the same
+// language when there is one. CreatedAt is kept on replace.
+func (r *SongLyricsRepository) Upsert(ctx context.Context, songLyrics *models.SongLyrics) (*models.SongLyrics, error) {
+	lyrics, err := marshalStanzas(songLyrics.Stanzas)
+	if err != nil {
+		return nil, fmt.Errorf("SongLyricsRepo/Upsert: %w", err)
+	}
+
+	query := `
+		INSERT INTO song_lyrics (song_id, language, song_text, lyrics, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (song_id, language) DO UPDATE
+		SET song_text = EXCLUDED.song_text, lyrics = EXCLUDED.lyrics, updated_at = EXCLUDED.updated_at
+		RETURNING created_at
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	res := *songLyrics
+	row := r.db.QueryRowxContext(ctx, query, songLyrics.SongID, songLyrics.Language, songLyrics.SongText,
+		lyrics, songLyrics.CreatedAt, songLyrics.UpdatedAt)
+	if err := row.Scan(&res.CreatedAt); err != nil {
+		return nil, fmt.Errorf("SongLyricsRepo/Upsert: error: %w", classifyError(err))
+	}
+
+	return &res, nil
+}
+
+// Get returns the lyrics of the song in the language, or nil when there are
+// none.
+func (r *SongLyricsRepository) Get(ctx context.Context, songID int64, language string) (*models.SongLyrics, error) {
+	query := `
+		SELECT l.song_id, l.language, l.song_text, l.lyrics, l.created_at, l.updated_at
+		FROM song_lyrics l
+		JOIN songs s ON s.id = l.song_id AND s.deleted_at IS NULL
+		WHERE l.song_id = $1 AND l.language = $2
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	var (
+		res    models.SongLyrics
+		lyrics []byte
+	)
+	row := r.db.QueryRowxContext(ctx, query, songID, language)
+	if err := row.Scan(&res.SongID, &res.Language, &res.SongText, &lyrics, &res.CreatedAt, &res.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("SongLyricsRepo/Get: error: %w", err)
+	}
+
+	if err := json.Unmarshal(lyrics, &res.Stanzas); err != nil {
+		return nil, fmt.Errorf("SongLyricsRepo/Get: error decoding lyrics: %w", err)
+	}
+
+	return &res, nil
+}
+
+// GetBySongID returns the lyrics of the song in every language without their
+// parsed stanzas.
+func (r *SongLyricsRepository) GetBySongID(ctx context.Context, songID int64) ([]*models.SongLyrics, error) {
+	query := `
+		SELECT l.song_id, l.language, l.song_text, l.created_at, l.updated_at
+		FROM song_lyrics l
+		JOIN songs s ON s.id = l.song_id AND s.deleted_at IS NULL
+		WHERE l.song_id = $1
+		ORDER BY l.language
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	rows, err := r.db.QueryContext(ctx, query, songID)
+	if err != nil {
+		return nil, fmt.Errorf("SongLyricsRepo/GetBySongID: error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	res := []*models.SongLyrics{}
+	for rows.Next() {
+		var songLyrics models.SongLyrics
+		if err := rows.Scan(&songLyrics.SongID, &songLyrics.Language, &songLyrics.SongText,
+			&songLyrics.CreatedAt, &songLyrics.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("SongLyricsRepo/GetBySongID: error scanning row: %w", err)
+		}
+		res = append(res, &songLyrics)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("SongLyricsRepo/GetBySongID: error iterating rows: %w", err)
+	}
+
+	return res, nil
+}
+
+func (r *SongLyricsRepository) GetLanguages(ctx context.Context, songID int64) ([]string, error) {
+	query := `
+		SELECT l.language
+		FROM song_lyrics l
+		JOIN songs s ON s.id = l.song_id AND s.deleted_at IS NULL
+		WHERE l.song_id = $1
+		ORDER BY l.language
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	rows, err := r.db.QueryContext(ctx, query, songID)
+	if err != nil {
+		return nil, fmt.Errorf("SongLyricsRepo/GetLanguages: error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	var languages []string
+	for rows.Next() {
+		var language string
+		if err := rows.Scan(&language); err != nil {
+			return nil, fmt.Errorf("SongLyricsRepo/GetLanguages: error scanning row: %w", err)
+		}
+		languages = append(languages, language)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("SongLyricsRepo/GetLanguages: error iterating rows: %w", err)
+	}
+
+	return languages, nil
+}
+
+func (r *SongLyricsRepository) Delete(ctx context.Context, songID int64, language string) error {
+	query := `
+		DELETE FROM song_lyrics
+		WHERE song_id = $1 AND language = $2
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	_, err := r.db.ExecContext(ctx, query, songID, language)
+	if err != nil {
+		return fmt.Errorf("SongLyricsRepo/Delete: error: %w", err)
+	}
+
+	return nil
+}
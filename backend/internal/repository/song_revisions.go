@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salmon822/test_task/internal/pkg/logger"
+	"github.com/salmon822/test_task/internal/repository/models"
+)
+
+type SongRevisionsRepository struct {
+	db     sqlx.ExtContext
+	logger logger.Logger
+}
+
+func NewSongRevisionsRepository(
+	db *sqlx.DB,
+	logger logger.Logger,
+) SongRevisions {
+	return &SongRevisionsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *SongRevisionsRepository) WithTX(tx *sqlx.Tx) SongRevisions {
+	return &SongRevisionsRepository{
+		db:     tx,
+		logger: r.logger,
+	}
+}
+
+// Create stores the revision as the next one of the song and sets its
+// Revision.
+func (r *SongRevisionsRepository) Create(ctx context.Context, revision *models.SongRevision) (*models.SongRevision, error) {
+	snapshot, err := json.Marshal(revision.Snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("SongRevisionsRepo/Create: error encoding snapshot: %w", err)
+	}
+
+	query := `
+		INSERT INTO song_revisions (song_id, revision, action, snapshot, created_at)
+		SELECT $1, COALESCE(MAX(revision), 0) + 1, $2, $3, $4
+		FROM song_revisions
+		WHERE song_id = $1
+		RETURNING id, revision
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	res := *revision
+	row := r.db.QueryRowxContext(ctx, query, revision.SongID, revision.Action, snapshot, revision.CreatedAt)
+	if err := row.Scan(&res.ID, &res.Revision); err != nil {
+		return nil, fmt.Errorf("SongRevisionsRepo/Create: error: %w", classifyError(err))
+	}
+
+	return &res, nil
+}
+
+// CreateBatch records the revisions with one statement, each of them as the
+// next revision of its song.
+func (r *SongRevisionsRepository) CreateBatch(ctx context.Context, revisions []*models.SongRevision) error {
+	if len(revisions) == 0 {
+		return nil
+	}
+
+	const columns = 4
+	values := make([]string, 0, len(revisions))
+	args := make([]any, 0, len(revisions)*columns)
+	for i, revision := range revisions {
+		snapshot, err := json.Marshal(revision.Snapshot)
+		if err != nil {
+			return fmt.Errorf("SongRevisionsRepo/CreateBatch: error encoding snapshot: %w", err)
+		}
+
+		n := i * columns
+		values = append(values, fmt.Sprintf("($%d::int, $%d::varchar, $%d::jsonb, $%d::bigint)", n+1, n+2, n+3, n+4))
+		args = append(args, revision.SongID, revision.Action, snapshot, revision.CreatedAt)
+	}
+
+	query := `
+		INSERT INTO song_revisions (song_id, revision, action, snapshot, created_at)
+		SELECT v.song_id, COALESCE((SELECT MAX(sr.revision) FROM song_revisions sr WHERE sr.song_id = v.song_id), 0) + 1,
+			v.action, v.snapshot, v.created_at
+		FROM (VALUES ` + strings.Join(values, ", ") + `) AS v (song_id, action, snapshot, created_at)
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("SongRevisionsRepo/CreateBatch: error: %w", classifyError(err))
+	}
+
+	return nil
+}
+
+func (r *SongRevisionsRepository) GetBySongID(ctx context.Context, songID int64) ([]*models.SongRevision, error) {
+	query := `
+		SELECT id, song_id, revision, action, snapshot, created_at
+		FROM song_revisions
+		WHERE song_id = $1
+		ORDER BY revision
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	rows, err := r.db.QueryContext(ctx, query, songID)
+	if err != nil {
+		return nil, fmt.Errorf("SongRevisionsRepo/GetBySongID: error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []*models.SongRevision{}
+	for rows.Next() {
+		revision, err := scanSongRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("SongRevisionsRepo/GetBySongID: error scanning row: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("SongRevisionsRepo/GetBySongID: error iterating rows: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// Get returns the revision of the song, or nil when there is no such revision.
+func (r *SongRevisionsRepository) Get(ctx context.Context, songID int64, revision int64) (*models.SongRevision, error) {
+	query := `
+		SELECT id, song_id, revision, action, snapshot, created_at
+		FROM song_revisions
+		WHERE song_id = $1 AND revision = $2
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	res, err := scanSongRevision(r.db.QueryRowxContext(ctx, query, songID, revision))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("SongRevisionsRepo/Get: error: %w", err)
+	}
+
+	return res, nil
+}
+
+func scanSongRevision(row rowScanner) (*models.SongRevision, error) {
+	var (
+		revision models.SongRevision
+		snapshot []byte
+	)
+	if err := row.Scan(&revision.ID, &revision.SongID, &revision.Revision, &revision.Action,
+		&snapshot, &revision.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(snapshot, &revision.Snapshot); err != nil {
+		return nil, fmt.Errorf("error decoding snapshot: %w", err)
+	}
+
+	return &revision, nil
+}
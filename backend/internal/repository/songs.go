@@ -2,15 +2,62 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jmoiron/sqlx"
+	"github.com/salmon822/test_task/internal/domain"
 	"github.com/salmon822/test_task/internal/pkg/logger"
 	"github.com/salmon822/test_task/internal/repository/models"
 )
 
+// songColumns is the column list shared by every query returning full songs,
+// it must be kept in sync with scanSong.
+const songColumns = `
+	s.id, s.artist_id, a.name, s.song_title, s.release_date, s.song_text, s.link,
+	s.created_at, s.updated_at, s.enrichment_status,
+	COALESCE(s.album_id, 0), COALESCE(s.disc_number, 0), COALESCE(s.track_number, 0),
+	COALESCE(s.deleted_at, 0), s.version
+`
+
+// songColumnsWithoutText selects the same columns as songColumns but leaves
+// the lyrics out, which are by far the largest of them.
+const songColumnsWithoutText = `
+	s.id, s.artist_id, a.name, s.song_title, s.release_date, '' AS song_text, s.link,
+	s.created_at, s.updated_at, s.enrichment_status,
+	COALESCE(s.album_id, 0), COALESCE(s.disc_number, 0), COALESCE(s.track_number, 0),
+	COALESCE(s.deleted_at, 0), s.version
+`
+
+func countDistinct(values []string) int {
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		seen[value] = struct{}{}
+	}
+	return len(seen)
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanSong reads a row selected with songColumns into song, extra receives the
+// columns selected after them.
+func scanSong(row rowScanner, song *models.Song, extra ...any) error {
+	dest := []any{&song.ID, &song.ArtistID, &song.GroupName, &song.SongTitle,
+		&song.ReleaseDate, &song.SongText, &song.Link,
+		&song.CreatedAt, &song.UpdatedAt, &song.EnrichmentStatus,
+		&song.AlbumID, &song.DiscNumber, &song.TrackNumber,
+		&song.DeletedAt, &song.Version}
+
+	return row.Scan(append(dest, extra...)...)
+}
+
 type SongsRepository struct {
 	db     sqlx.ExtContext
 	logger logger.Logger
@@ -33,65 +80,170 @@ func (r *SongsRepository) WithTX(tx *sqlx.Tx) Songs {
 	}
 }
 
+// Create inserts the song. The ID of the song is kept when it is set, e.g.
+// when a deleted song is restored, otherwise a new one is assigned.
 func (r *SongsRepository) Create(ctx context.Context, song *models.Song) (*models.Song, error) {
 	if r.logger == nil {
 		return nil, fmt.Errorf("SongsRepo/Create: logger is nil")
 	}
+	lyrics, err := marshalStanzas(song.Stanzas)
+	if err != nil {
+		return nil, fmt.Errorf("SongsRepo/Create: %w", err)
+	}
+
 	query := `
-		INSERT INTO songs (id, group_name, song_title, release_date, song_text, link, created_at, updated_at)
-		VALUES (default, $1, $2, $3, $4, $5, $6, $7)
-		RETURNING id
+		INSERT INTO songs (id, artist_id, song_title, release_date, song_text, link, created_at, updated_at,
+			enrichment_status, enrichment_attempts, enrichment_last_error, album_id, disc_number, track_number, lyrics)
+		VALUES (COALESCE(NULLIF($15, 0), nextval('songs_id_seq')), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+			NULLIF($11, 0), NULLIF($12, 0), NULLIF($13, 0), $14)
+		RETURNING id, version
 	`
-	row := r.db.QueryRowxContext(ctx, query, song.GroupName, song.SongTitle, song.ReleaseDate,
-		song.SongText, song.Link, song.UpdatedAt, song.CreatedAt)
+	row := r.db.QueryRowxContext(ctx, query, song.ArtistID, song.SongTitle, song.ReleaseDate,
+		song.SongText, song.Link, song.CreatedAt, song.UpdatedAt,
+		song.EnrichmentStatus, song.EnrichmentAttempts, song.EnrichmentLastError,
+		song.AlbumID, song.DiscNumber, song.TrackNumber, lyrics, song.ID)
 
 	r.logger.Debugf("SQL Query: %s", query)
 
-	err := row.Scan(&song.ID)
+	err = row.Scan(&song.ID, &song.Version)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			r.logger.Warnf("No rows returned for song creation")
 			return nil, nil
 		}
-		return nil, fmt.Errorf("SongsRepo/Create: error: %w", err)
+		return nil, fmt.Errorf("SongsRepo/Create: error: %w", classifyError(err))
 	}
 
 	return song, nil
 }
 
-func (r *SongsRepository) Delete(ctx context.Context, id int64) error {
+// CreateBatch inserts the songs with one statement and returns them with the
+// IDs assigned by the database, in the same order.
+func (r *SongsRepository) CreateBatch(ctx context.Context, songs []*models.Song) ([]*models.Song, error) {
+	if len(songs) == 0 {
+		return nil, nil
+	}
+
+	const columns = 14
+	values := make([]string, 0, len(songs))
+	args := make([]any, 0, len(songs)*columns)
+	for i, song := range songs {
+		lyrics, err := marshalStanzas(song.Stanzas)
+		if err != nil {
+			return nil, fmt.Errorf("SongsRepo/CreateBatch: %w", err)
+		}
+
+		n := i * columns
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, NULLIF($%d, 0), NULLIF($%d, 0), NULLIF($%d, 0), $%d)",
+			n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9, n+10, n+11, n+12, n+13, n+14))
+		args = append(args, song.ArtistID, song.SongTitle, song.ReleaseDate,
+			song.SongText, song.Link, song.CreatedAt, song.UpdatedAt,
+			song.EnrichmentStatus, song.EnrichmentAttempts, song.EnrichmentLastError,
+			song.AlbumID, song.DiscNumber, song.TrackNumber, lyrics)
+	}
+
+	query := `
+		INSERT INTO songs (artist_id, song_title, release_date, song_text, link, created_at, updated_at,
+			enrichment_status, enrichment_attempts, enrichment_last_error, album_id, disc_number, track_number, lyrics)
+		VALUES ` + strings.Join(values, ", ") + `
+		RETURNING id, version
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("SongsRepo/CreateBatch: error executing query: %w", classifyError(err))
+	}
+	defer rows.Close()
+
+	res := make([]*models.Song, 0, len(songs))
+	for rows.Next() {
+		song := *songs[len(res)]
+		if err := rows.Scan(&song.ID, &song.Version); err != nil {
+			return nil, fmt.Errorf("SongsRepo/CreateBatch: error scanning row: %w", err)
+		}
+		res = append(res, &song)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("SongsRepo/CreateBatch: error iterating rows: %w", classifyError(err))
+	}
+
+	return res, nil
+}
+
+// Delete moves the song to the trash, it is removed for good by Purge.
+func (r *SongsRepository) Delete(ctx context.Context, id int64, deletedAt int64) error {
 	query := `
-		DELETE FROM songs
-		WHERE id = $1
+		UPDATE songs
+		SET deleted_at = $2
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	r.logger.Debugf("SQL Query: %s", query)
 
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := r.db.ExecContext(ctx, query, id, deletedAt)
 	if err != nil {
-		return fmt.Errorf("SongsRepo/Delete: error: %w", err)
+		return fmt.Errorf("SongsRepo/Delete: error: %w", classifyError(err))
 	}
 
 	return nil
 }
 
-func (r *SongsRepository) GetById(ctx context.Context, id int64) (*models.Song, error) {
+// Restore takes the song out of the trash and reports whether it was there.
+func (r *SongsRepository) Restore(ctx context.Context, id int64) (bool, error) {
 	query := `
-		SELECT id, group_name, song_title, release_date, song_text, link, created_at, updated_at 
-		FROM songs
-		WHERE id = $1
+		UPDATE songs
+		SET deleted_at = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
 	`
 
 	r.logger.Debugf("SQL Query: %s", query)
 
-	var song = &models.Song{
-		ID: id,
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("SongsRepo/Restore: error: %w", classifyError(err))
 	}
 
-	row := r.db.QueryRowxContext(ctx, query, id)
-	err := row.Scan(&song.ID, &song.GroupName, &song.SongTitle,
-		&song.ReleaseDate, &song.SongText, &song.Link,
-		&song.CreatedAt, &song.UpdatedAt)
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("SongsRepo/Restore: error: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// Purge permanently removes up to limit songs moved to the trash before
+// deletedBefore, oldest first, and returns how many were removed.
+func (r *SongsRepository) Purge(ctx context.Context, deletedBefore int64, limit int64) (int64, error) {
+	query := `
+		DELETE FROM songs
+		WHERE id IN (
+			SELECT id
+			FROM songs
+			WHERE deleted_at < $1
+			ORDER BY deleted_at, id
+			LIMIT $2
+		)
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	res, err := r.db.ExecContext(ctx, query, deletedBefore, limit)
+	if err != nil {
+		return 0, fmt.Errorf("SongsRepo/Purge: error: %w", err)
+	}
+
+	purged, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("SongsRepo/Purge: error: %w", err)
+	}
+
+	return purged, nil
+}
+
+func (r *SongsRepository) GetById(ctx context.Context, id int64) (*models.Song, error) {
+	song, err := r.getById(ctx, id, songColumns)
 	if err != nil {
 		return nil, fmt.Errorf("SongsRepo/GetById: error: %w", err)
 	}
@@ -99,52 +251,431 @@ func (r *SongsRepository) GetById(ctx context.Context, id int64) (*models.Song,
 	return song, nil
 }
 
+// GetByIdWithoutText reads the song like GetById without selecting its
+// lyrics, SongText is left empty.
+func (r *SongsRepository) GetByIdWithoutText(ctx context.Context, id int64) (*models.Song, error) {
+	song, err := r.getById(ctx, id, songColumnsWithoutText)
+	if err != nil {
+		return nil, fmt.Errorf("SongsRepo/GetByIdWithoutText: error: %w", err)
+	}
+
+	return song, nil
+}
+
+func (r *SongsRepository) getById(ctx context.Context, id int64, columns string) (*models.Song, error) {
+	query := `
+		SELECT ` + columns + `
+		FROM songs s
+		JOIN artists a ON a.id = s.artist_id
+		WHERE s.id = $1 AND s.deleted_at IS NULL
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	var song models.Song
+	row := r.db.QueryRowxContext(ctx, query, id)
+	if err := scanSong(row, &song); err != nil {
+		return nil, classifyError(err)
+	}
+
+	return &song, nil
+}
+
+// Exists reports whether the song exists and is not in the trash.
+// Exists reports whether the song exists, songs in the trash only counting
+// when includeDeleted is set.
+func (r *SongsRepository) Exists(ctx context.Context, id int64, includeDeleted bool) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM songs WHERE id = $1 AND ($2 OR deleted_at IS NULL))
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	var exists bool
+	if err := r.db.QueryRowxContext(ctx, query, id, includeDeleted).Scan(&exists); err != nil {
+		return false, fmt.Errorf("SongsRepo/Exists: error: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetByIdForUpdate reads the song and locks its row until the end of the
+// transaction. It returns nil when the song does not exist.
+func (r *SongsRepository) GetByIdForUpdate(ctx context.Context, id int64) (*models.Song, error) {
+	query := `
+		SELECT ` + songColumns + `
+		FROM songs s
+		JOIN artists a ON a.id = s.artist_id
+		WHERE s.id = $1 AND s.deleted_at IS NULL
+		FOR UPDATE OF s
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	var song models.Song
+	row := r.db.QueryRowxContext(ctx, query, id)
+	if err := scanSong(row, &song); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("SongsRepo/GetByIdForUpdate: error: %w", err)
+	}
+
+	return &song, nil
+}
+
+// Update writes the song and bumps its version. The creation time is kept.
 func (r *SongsRepository) Update(ctx context.Context, data *models.Song) (*models.Song, error) {
+	lyrics, err := marshalStanzas(data.Stanzas)
+	if err != nil {
+		return nil, fmt.Errorf("SongsRepo/Update: %w", err)
+	}
+
 	query := `
 		UPDATE songs 
-		SET group_name = $2, link = $3, release_date = $4, song_text = $5, song_title = $6
+		SET artist_id = $2, link = $3, release_date = $4, song_text = $5, song_title = $6,
+			album_id = NULLIF($7, 0), disc_number = NULLIF($8, 0), track_number = NULLIF($9, 0), lyrics = $10,
+			updated_at = $11, version = version + 1
 		WHERE id = $1
+		RETURNING version
 	`
 
 	r.logger.Debugf("SQL Query: %s", query)
 
-	_, err := r.db.ExecContext(ctx, query, data.ID, data.GroupName, data.Link, data.ReleaseDate, data.SongText, data.SongTitle)
-	if err != nil {
-		return nil, fmt.Errorf("SongsRepo/Update: error: %w", err)
+	res := *data
+	row := r.db.QueryRowxContext(ctx, query, data.ID, data.ArtistID, data.Link, data.ReleaseDate, data.SongText, data.SongTitle,
+		data.AlbumID, data.DiscNumber, data.TrackNumber, lyrics, data.UpdatedAt)
+	if err := row.Scan(&res.Version); err != nil {
+		return nil, fmt.Errorf("SongsRepo/Update: error: %w", classifyError(err))
 	}
 
-	return data, nil
+	return &res, nil
 }
 
-func (r *SongsRepository) GetFilteredSongs(ctx context.Context, filters *models.SongFilters, page int64, pageSize int64) ([]*models.Song, error) {
+// GetStanzas returns the parsed text of the song, or nil when the song was
+// written before its text started being parsed.
+func (r *SongsRepository) GetStanzas(ctx context.Context, id int64) ([]models.Stanza, error) {
 	query := `
-		SELECT id, group_name, song_title, release_date, song_text, link, created_at, updated_at
+		SELECT lyrics
 		FROM songs
+		WHERE id = $1
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	var lyrics []byte
+	if err := r.db.QueryRowxContext(ctx, query, id).Scan(&lyrics); err != nil {
+		return nil, fmt.Errorf("SongsRepo/GetStanzas: error: %w", err)
+	}
+	if lyrics == nil {
+		return nil, nil
+	}
+
+	var stanzas []models.Stanza
+	if err := json.Unmarshal(lyrics, &stanzas); err != nil {
+		return nil, fmt.Errorf("SongsRepo/GetStanzas: error decoding lyrics: %w", err)
+	}
+
+	return stanzas, nil
+}
+
+func marshalStanzas(stanzas []models.Stanza) ([]byte, error) {
+	if stanzas == nil {
+		stanzas = []models.Stanza{}
+	}
+
+	lyrics, err := json.Marshal(stanzas)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding lyrics: %w", err)
+	}
+
+	return lyrics, nil
+}
+
+// songOrderTerm is one expression of the ORDER BY clause of a song listing,
+// key converts the matching cursor key decoded from JSON to the column type.
+type songOrderTerm struct {
+	expr string
+	desc bool
+	key  func(value any) (any, error)
+}
+
+func textCursorKey(value any) (any, error) {
+	if v, ok := value.(string); ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("expected a string cursor key, got %T", value)
+}
+
+func intCursorKey(value any) (any, error) {
+	if v, ok := value.(float64); ok && v == float64(int64(v)) {
+		return int64(v), nil
+	}
+	return nil, fmt.Errorf("expected an integer cursor key, got %v", value)
+}
+
+func dateCursorKey(value any) (any, error) {
+	if v, ok := value.(string); ok {
+		return time.Parse(time.DateOnly, v)
+	}
+	return nil, fmt.Errorf("expected a date cursor key, got %T", value)
+}
+
+func realCursorKey(value any) (any, error) {
+	if v, ok := value.(float64); ok {
+		return float32(v), nil
+	}
+	return nil, fmt.Errorf("expected a number cursor key, got %T", value)
+}
+
+// songSortTerm returns the order term of a sort field, score is the
+// expression of the fuzzy similarity score of the listing.
+func songSortTerm(sort models.SongSort, score string) (songOrderTerm, error) {
+	term := songOrderTerm{desc: sort.Desc}
+	switch sort.Field {
+	case models.SongSortGroupName:
+		term.expr, term.key = "a.name", textCursorKey
+	case models.SongSortSongTitle:
+		term.expr, term.key = "s.song_title", textCursorKey
+	case models.SongSortReleaseDate:
+		term.expr, term.key = "COALESCE(s.release_date, DATE '0001-01-01')", dateCursorKey
+	case models.SongSortCreatedAt:
+		term.expr, term.key = "s.created_at", intCursorKey
+	case models.SongSortUpdatedAt:
+		term.expr, term.key = "s.updated_at", intCursorKey
+	case models.SongSortScore:
+		term.expr, term.key = score, realCursorKey
+	default:
+		return term, fmt.Errorf("unsupported sort field %q", sort.Field)
+	}
+
+	return term, nil
+}
+
+// songOrder returns the order terms of a listing. The song ID always comes
+// last so that the order, and the cursors based on it, are deterministic.
+// Sorting by ID only sets its direction.
+func songOrder(sorts []models.SongSort, score string) ([]songOrderTerm, error) {
+	var order []songOrderTerm
+	idTerm := songOrderTerm{expr: "s.id"}
+	for _, sort := range sorts {
+		if sort.Field == models.SongSortID {
+			idTerm.desc = sort.Desc
+			continue
+		}
+		term, err := songSortTerm(sort, score)
+		if err != nil {
+			return nil, err
+		}
+		order = append(order, term)
+	}
+
+	return append(order, idTerm), nil
+}
+
+// songOrderBy builds the ORDER BY list of the order, reversed when backward
+// is set.
+func songOrderBy(order []songOrderTerm, backward bool) string {
+	orderBy := make([]string, 0, len(order))
+	for _, term := range order {
+		if term.desc != backward {
+			orderBy = append(orderBy, term.expr+" DESC")
+		} else {
+			orderBy = append(orderBy, term.expr)
+		}
+	}
+
+	return strings.Join(orderBy, ", ")
+}
+
+// keysetCondition builds the predicate selecting the rows that come after the
+// cursor in the given order, or before it when backward is set.
+func keysetCondition(order []songOrderTerm, backward bool, argIndex int) string {
+	var alternatives []string
+	for i, term := range order {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, fmt.Sprintf("%s = $%d", order[j].expr, argIndex+j))
+		}
+
+		op := ">"
+		if term.desc != backward {
+			op = "<"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s $%d", term.expr, op, argIndex+i))
+
+		alternatives = append(alternatives, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return "(" + strings.Join(alternatives, " OR ") + ")"
+}
+
+// songFilter is the FROM and WHERE part of a song listing with its arguments
+// and the expression of the fuzzy similarity score.
+type songFilter struct {
+	query string
+	args  []any
+	score string
+}
+
+// buildSongFilter turns the filters into the part of the query shared by
+// GetFilteredSongs and CountFilteredSongs.
+func buildSongFilter(filters *models.SongFilters) *songFilter {
+	query := `
+		FROM songs s
+		JOIN artists a ON a.id = s.artist_id
 		WHERE 1=1
 	`
 	args := []interface{}{}
 	argIndex := 1
+	scores := []string{}
+
+	if !filters.IncludeDeleted {
+		query += " AND s.deleted_at IS NULL"
+	}
 
 	if filters.GroupName != nil {
-		query += fmt.Sprintf(" AND group_name ILIKE $%d", argIndex)
-		args = append(args, "%"+*filters.GroupName+"%")
+		if filters.Fuzzy {
+			query += fmt.Sprintf(" AND (a.name %% $%[1]d OR a.name ILIKE '%%' || $%[1]d::text || '%%')", argIndex)
+			scores = append(scores, fmt.Sprintf("similarity(a.name, $%d)", argIndex))
+			args = append(args, *filters.GroupName)
+		} else {
+			query += fmt.Sprintf(" AND a.name ILIKE $%d", argIndex)
+			args = append(args, "%"+*filters.GroupName+"%")
+		}
+		argIndex++
+	}
+
+	if filters.ArtistID != nil {
+		query += fmt.Sprintf(" AND s.artist_id = $%d", argIndex)
+		args = append(args, *filters.ArtistID)
+		argIndex++
+	}
+
+	if filters.AlbumID != nil {
+		query += fmt.Sprintf(" AND s.album_id = $%d", argIndex)
+		args = append(args, *filters.AlbumID)
 		argIndex++
 	}
 
 	if filters.SongTitle != nil {
-		query += fmt.Sprintf(" AND song_title ILIKE $%d", argIndex)
-		args = append(args, "%"+*filters.SongTitle+"%")
+		if filters.Fuzzy {
+			query += fmt.Sprintf(" AND (s.song_title %% $%[1]d OR s.song_title ILIKE '%%' || $%[1]d::text || '%%')", argIndex)
+			scores = append(scores, fmt.Sprintf("similarity(s.song_title, $%d)", argIndex))
+			args = append(args, *filters.SongTitle)
+		} else {
+			query += fmt.Sprintf(" AND s.song_title ILIKE $%d", argIndex)
+			args = append(args, "%"+*filters.SongTitle+"%")
+		}
 		argIndex++
 	}
 
 	if filters.ReleaseDate != nil {
-		query += fmt.Sprintf(" AND release_date = $%d", argIndex)
+		query += fmt.Sprintf(" AND s.release_date = $%d", argIndex)
 		args = append(args, *filters.ReleaseDate)
 		argIndex++
 	}
 
-	query += fmt.Sprintf(" ORDER BY id LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
-	args = append(args, pageSize, (page-1)*pageSize)
+	if filters.ReleasedFrom != nil {
+		query += fmt.Sprintf(" AND s.release_date >= $%d", argIndex)
+		args = append(args, *filters.ReleasedFrom)
+		argIndex++
+	}
+
+	if filters.ReleasedTo != nil {
+		query += fmt.Sprintf(" AND s.release_date <= $%d", argIndex)
+		args = append(args, *filters.ReleasedTo)
+		argIndex++
+	}
+
+	if len(filters.Tags) > 0 {
+		tags := make([]string, 0, len(filters.Tags))
+		for _, tag := range filters.Tags {
+			tags = append(tags, strings.ToLower(strings.Join(strings.Fields(tag), " ")))
+		}
+
+		tagsQuery := fmt.Sprintf(`
+			SELECT COUNT(DISTINCT lower(regexp_replace(btrim(t.name), '\s+', ' ', 'g')))
+			FROM song_tags st
+			JOIN tags t ON t.id = st.tag_id
+			WHERE st.song_id = s.id AND t.kind = 'tag' AND lower(regexp_replace(btrim(t.name), '\s+', ' ', 'g')) = ANY($%d)`, argIndex)
+		args = append(args, tags)
+		argIndex++
+
+		if filters.TagsMatch == models.TagsMatchAll {
+			query += fmt.Sprintf(" AND (%s) = $%d", tagsQuery, argIndex)
+			args = append(args, countDistinct(tags))
+			argIndex++
+		} else {
+			query += fmt.Sprintf(" AND (%s) > 0", tagsQuery)
+		}
+	}
+
+	if filters.Genre != nil {
+		query += fmt.Sprintf(`
+			AND EXISTS (
+				SELECT 1
+				FROM song_tags st
+				JOIN tags t ON t.id = st.tag_id
+				WHERE st.song_id = s.id AND t.kind = 'genre' AND lower(regexp_replace(btrim(t.name), '\s+', ' ', 'g')) = lower(regexp_replace(btrim($%d), '\s+', ' ', 'g'))
+			)`, argIndex)
+		args = append(args, *filters.Genre)
+		argIndex++
+	}
+
+	score := "0::real"
+	switch len(scores) {
+	case 0:
+	case 1:
+		score = scores[0]
+	default:
+		score = "GREATEST(" + strings.Join(scores, ", ") + ")"
+	}
+
+	return &songFilter{query: query, args: args, score: score}
+}
+
+func (r *SongsRepository) GetFilteredSongs(ctx context.Context, filters *models.SongFilters, pagination *models.SongPagination) ([]*models.Song, error) {
+	if filters.Fuzzy {
+		if err := r.setSimilarityThreshold(ctx); err != nil {
+			return nil, fmt.Errorf("SongsRepo/GetFilteredSongs: %w", err)
+		}
+	}
+
+	filter := buildSongFilter(filters)
+	query, args, score := filter.query, filter.args, filter.score
+	argIndex := len(args) + 1
+
+	order, err := songOrder(pagination.Sort, score)
+	if err != nil {
+		return nil, fmt.Errorf("SongsRepo/GetFilteredSongs: %w", err)
+	}
+
+	cursor, backward := pagination.After, false
+	if pagination.Before != nil {
+		cursor, backward = pagination.Before, true
+	}
+	if cursor != nil {
+		if len(cursor.Keys) != len(order)-1 {
+			return nil, fmt.Errorf("SongsRepo/GetFilteredSongs: %w: cursor does not match the listing order", domain.ErrInvalidRequest)
+		}
+		for i, value := range cursor.Keys {
+			key, err := order[i].key(value)
+			if err != nil {
+				return nil, fmt.Errorf("SongsRepo/GetFilteredSongs: %w: invalid cursor: %w", domain.ErrInvalidRequest, err)
+			}
+			args = append(args, key)
+		}
+		args = append(args, cursor.ID)
+
+		query += " AND " + keysetCondition(order, backward, argIndex)
+		argIndex += len(order)
+	}
+
+	query = "SELECT " + songColumns + ", " + score + " AS score" + query
+	query += " ORDER BY " + songOrderBy(order, backward)
+	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, pagination.Limit, pagination.Offset)
 
 	r.logger.Debugf("SQL Query: %s", query)
 	r.logger.Debugf("Query Arguments: %+v", args)
@@ -158,12 +689,277 @@ func (r *SongsRepository) GetFilteredSongs(ctx context.Context, filters *models.
 	var songs []*models.Song
 	for rows.Next() {
 		var song models.Song
-		err := rows.Scan(&song.ID, &song.GroupName, &song.SongTitle, &song.ReleaseDate, &song.SongText, &song.Link, &song.CreatedAt, &song.UpdatedAt)
-		if err != nil {
+		if err := scanSong(rows, &song, &song.Score); err != nil {
 			return nil, fmt.Errorf("SongsRepo/GetFilteredSongs: error scanning row: %w", err)
 		}
 		songs = append(songs, &song)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("SongsRepo/GetFilteredSongs: error iterating rows: %w", classifyError(err))
+	}
 
 	return songs, nil
 }
+
+// songExportCursor is the server-side cursor an export reads the songs from.
+const songExportCursor = "song_export"
+
+// ExportSongs reads the songs matching the filters through a server-side
+// cursor, batchSize songs at a time, and passes every batch to fn. It must run
+// in a transaction, as the cursor lives until the end of it.
+func (r *SongsRepository) ExportSongs(ctx context.Context, filters *models.SongFilters, sort []models.SongSort,
+	batchSize int64, fn func([]*models.Song) error) error {
+	if filters.Fuzzy {
+		if err := r.setSimilarityThreshold(ctx); err != nil {
+			return fmt.Errorf("SongsRepo/ExportSongs: %w", err)
+		}
+	}
+
+	filter := buildSongFilter(filters)
+	order, err := songOrder(sort, filter.score)
+	if err != nil {
+		return fmt.Errorf("SongsRepo/ExportSongs: %w: %w", domain.ErrInvalidRequest, err)
+	}
+
+	query := "DECLARE " + songExportCursor + " NO SCROLL CURSOR FOR SELECT " + songColumns + filter.query +
+		" ORDER BY " + songOrderBy(order, false)
+
+	r.logger.Debugf("SQL Query: %s", query)
+	r.logger.Debugf("Query Arguments: %+v", filter.args)
+
+	if _, err := r.db.ExecContext(ctx, query, filter.args...); err != nil {
+		return fmt.Errorf("SongsRepo/ExportSongs: error declaring cursor: %w", err)
+	}
+
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM %s", batchSize, songExportCursor)
+	for {
+		songs, err := r.fetchSongs(ctx, fetch)
+		if err != nil {
+			return fmt.Errorf("SongsRepo/ExportSongs: %w", err)
+		}
+		if len(songs) == 0 {
+			break
+		}
+		if err := fn(songs); err != nil {
+			return err
+		}
+	}
+
+	if _, err := r.db.ExecContext(ctx, "CLOSE "+songExportCursor); err != nil {
+		return fmt.Errorf("SongsRepo/ExportSongs: error closing cursor: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SongsRepository) fetchSongs(ctx context.Context, query string) ([]*models.Song, error) {
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching rows: %w", err)
+	}
+	defer rows.Close()
+
+	var songs []*models.Song
+	for rows.Next() {
+		var song models.Song
+		if err := scanSong(rows, &song); err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+		songs = append(songs, &song)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return songs, nil
+}
+
+func (r *SongsRepository) CountFilteredSongs(ctx context.Context, filters *models.SongFilters) (int64, error) {
+	if filters.Fuzzy {
+		if err := r.setSimilarityThreshold(ctx); err != nil {
+			return 0, fmt.Errorf("SongsRepo/CountFilteredSongs: %w", err)
+		}
+	}
+
+	filter := buildSongFilter(filters)
+	query := "SELECT COUNT(*)" + filter.query
+
+	r.logger.Debugf("SQL Query: %s", query)
+	r.logger.Debugf("Query Arguments: %+v", filter.args)
+
+	var count int64
+	if err := r.db.QueryRowxContext(ctx, query, filter.args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("SongsRepo/CountFilteredSongs: error executing query: %w", err)
+	}
+
+	return count, nil
+}
+
+// fuzzySimilarityThreshold is lower than the pg_trgm default of 0.3 so that
+// short misspelled names, like "Mues" for "Muse", are still matched.
+const fuzzySimilarityThreshold = "0.2"
+
+// setSimilarityThreshold sets the threshold used by the % operator for the
+// rest of the current transaction.
+func (r *SongsRepository) setSimilarityThreshold(ctx context.Context) error {
+	query := `SELECT set_config('pg_trgm.similarity_threshold', $1, true)`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	if _, err := r.db.ExecContext(ctx, query, fuzzySimilarityThreshold); err != nil {
+		return fmt.Errorf("error setting similarity threshold: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimSongsToEnrich returns up to limit pending songs due for enrichment and
+// postpones their next attempt to claimedUntil, so that other workers skip
+// them meanwhile. Saving the outcome of the attempt releases the claim, songs
+// of a worker that stopped before that are due again after claimedUntil.
+func (r *SongsRepository) ClaimSongsToEnrich(ctx context.Context, now int64, claimedUntil int64, limit int64) ([]*models.Song, error) {
+	query := `
+		UPDATE songs s
+		SET enrichment_next_attempt_at = $2
+		FROM artists a
+		WHERE a.id = s.artist_id AND s.id IN (
+			SELECT id
+			FROM songs
+			WHERE enrichment_status = 'pending' AND enrichment_next_attempt_at <= $1 AND deleted_at IS NULL
+			ORDER BY enrichment_next_attempt_at, id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING s.id, s.artist_id, a.name, s.song_title, s.release_date, COALESCE(s.song_text, ''), COALESCE(s.link, ''),
+			s.created_at, s.updated_at, s.enrichment_status, s.enrichment_attempts, s.enrichment_last_error
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	rows, err := r.db.QueryContext(ctx, query, now, claimedUntil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("SongsRepo/ClaimSongsToEnrich: error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	var songs []*models.Song
+	for rows.Next() {
+		var song models.Song
+		err := rows.Scan(&song.ID, &song.ArtistID, &song.GroupName, &song.SongTitle, &song.ReleaseDate, &song.SongText, &song.Link,
+			&song.CreatedAt, &song.UpdatedAt, &song.EnrichmentStatus, &song.EnrichmentAttempts, &song.EnrichmentLastError)
+		if err != nil {
+			return nil, fmt.Errorf("SongsRepo/ClaimSongsToEnrich: error scanning row: %w", err)
+		}
+		songs = append(songs, &song)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("SongsRepo/ClaimSongsToEnrich: error iterating rows: %w", err)
+	}
+
+	return songs, nil
+}
+
+// SaveEnrichment stores the enriched fields of the song, bumps its version and
+// returns it. Columns that were filled in by a client since the song was read
+// are left untouched. It fails with ErrNotFound when the song was moved to the
+// trash in the meantime.
+func (r *SongsRepository) SaveEnrichment(ctx context.Context, song *models.Song) (*models.Song, error) {
+	query := `
+		UPDATE songs s
+		SET release_date = COALESCE(s.release_date, $2),
+			song_text = CASE WHEN COALESCE(s.song_text, '') = '' THEN $3 ELSE s.song_text END,
+			lyrics = CASE WHEN COALESCE(s.song_text, '') = '' THEN $7 ELSE s.lyrics END,
+			link = CASE WHEN COALESCE(s.link, '') = '' THEN $4 ELSE s.link END,
+			enrichment_status = $5,
+			enrichment_attempts = $6,
+			enrichment_last_error = $9,
+			updated_at = $8,
+			version = s.version + 1
+		FROM artists a
+		WHERE s.id = $1 AND a.id = s.artist_id AND s.deleted_at IS NULL
+		RETURNING ` + songColumns + `
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	lyrics, err := marshalStanzas(song.Stanzas)
+	if err != nil {
+		return nil, fmt.Errorf("SongsRepo/SaveEnrichment: %w", err)
+	}
+
+	var res models.Song
+	row := r.db.QueryRowxContext(ctx, query, song.ID, song.ReleaseDate, song.SongText, song.Link,
+		song.EnrichmentStatus, song.EnrichmentAttempts, lyrics, song.UpdatedAt, song.EnrichmentLastError)
+	if err := scanSong(row, &res); err != nil {
+		return nil, fmt.Errorf("SongsRepo/SaveEnrichment: error: %w", classifyError(err))
+	}
+
+	return &res, nil
+}
+
+func (r *SongsRepository) SaveEnrichmentFailure(ctx context.Context, failure *models.SongEnrichmentFailure) error {
+	query := `
+		UPDATE songs
+		SET enrichment_status = $2,
+			enrichment_attempts = $3,
+			enrichment_last_error = $4,
+			enrichment_next_attempt_at = $5
+		WHERE id = $1
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	_, err := r.db.ExecContext(ctx, query, failure.ID, failure.Status, failure.Attempts,
+		failure.LastError, failure.NextAttemptAt)
+	if err != nil {
+		return fmt.Errorf("SongsRepo/SaveEnrichmentFailure: error: %w", err)
+	}
+
+	return nil
+}
+
+// searchVectorColumns maps a text search configuration to the generated
+// tsvector column built with it.
+var searchVectorColumns = map[string]string{
+	models.SearchLanguageEnglish: "s.search_vector_en",
+	models.SearchLanguageRussian: "s.search_vector_ru",
+}
+
+func (r *SongsRepository) SearchSongs(ctx context.Context, search *models.SongSearch, page int64, pageSize int64) ([]*models.SongSearchResult, error) {
+	column, ok := searchVectorColumns[search.Language]
+	if !ok {
+		return nil, fmt.Errorf("SongsRepo/SearchSongs: unsupported language %q", search.Language)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT `+songColumns+`,
+			ts_rank(%[1]s, q.query) AS rank,
+			ts_headline('%[2]s', COALESCE(s.song_text, ''), q.query,
+				'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5') AS snippet
+		FROM songs s
+		JOIN artists a ON a.id = s.artist_id
+		CROSS JOIN websearch_to_tsquery('%[2]s', $1) AS q(query)
+		WHERE %[1]s @@ q.query AND s.deleted_at IS NULL
+		ORDER BY rank DESC, s.id
+		LIMIT $2 OFFSET $3
+	`, column, search.Language)
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	rows, err := r.db.QueryContext(ctx, query, search.Query, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("SongsRepo/SearchSongs: error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*models.SongSearchResult
+	for rows.Next() {
+		var result models.SongSearchResult
+		if err := scanSong(rows, &result.Song, &result.Rank, &result.Snippet); err != nil {
+			return nil, fmt.Errorf("SongsRepo/SearchSongs: error scanning row: %w", err)
+		}
+		results = append(results, &result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salmon822/test_task/internal/pkg/logger"
+	"github.com/salmon822/test_task/internal/repository/models"
+)
+
+type SyncedLyricsRepository struct {
+	db     sqlx.ExtContext
+	logger logger.Logger
+}
+
+func NewSyncedLyricsRepository(
+	db *sqlx.DB,
+	logger logger.Logger,
+) SyncedLyrics {
+	return &SyncedLyricsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *SyncedLyricsRepository) WithTX(tx *sqlx.Tx) SyncedLyrics {
+	return &SyncedLyricsRepository{
+		db:     tx,
+		logger: r.logger,
+	}
+}
+
+// Replace stores the lines as the synced lyrics of the song, dropping the
+// ones stored before.
+func (r *SyncedLyricsRepository) Replace(ctx context.Context, songID int64, lines []*models.SyncedLine) error {
+	deleteQuery := `
+		DELETE FROM song_synced_lyrics
+		WHERE song_id = $1
+	`
+
+	r.logger.Debugf("SQL Query: %s", deleteQuery)
+
+	if _, err := r.db.ExecContext(ctx, deleteQuery, songID); err != nil {
+		return fmt.Errorf("SyncedLyricsRepo/Replace: error deleting lines: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO song_synced_lyrics (song_id, line_number, start_ms, text)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	r.logger.Debugf("SQL Query: %s", insertQuery)
+
+	for _, line := range lines {
+		if _, err := r.db.ExecContext(ctx, insertQuery, songID, line.Number, line.StartMs, line.Text); err != nil {
+			return fmt.Errorf("SyncedLyricsRepo/Replace: error inserting line %d: %w", line.Number, classifyError(err))
+		}
+	}
+
+	return nil
+}
+
+func (r *SyncedLyricsRepository) GetBySongID(ctx context.Context, songID int64) ([]*models.SyncedLine, error) {
+	query := `
+		SELECT l.song_id, l.line_number, l.start_ms, l.text
+		FROM song_synced_lyrics l
+		JOIN songs s ON s.id = l.song_id AND s.deleted_at IS NULL
+		WHERE l.song_id = $1
+		ORDER BY l.start_ms
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	rows, err := r.db.QueryContext(ctx, query, songID)
+	if err != nil {
+		return nil, fmt.Errorf("SyncedLyricsRepo/GetBySongID: error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	lines := []*models.SyncedLine{}
+	for rows.Next() {
+		var line models.SyncedLine
+		if err := rows.Scan(&line.SongID, &line.Number, &line.StartMs, &line.Text); err != nil {
+			return nil, fmt.Errorf("SyncedLyricsRepo/GetBySongID: error scanning row: %w", err)
+		}
+		lines = append(lines, &line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("SyncedLyricsRepo/GetBySongID: error iterating rows: %w", err)
+	}
+
+	return lines, nil
+}
+
+// GetLinesAround returns the line active at the position, in milliseconds, and
+// the line following it. Either is nil when there is no such line.
+func (r *SyncedLyricsRepository) GetLinesAround(ctx context.Context, songID int64, position int64) (*models.SyncedLine, *models.SyncedLine, error) {
+	query := `
+		(SELECT l.song_id, l.line_number, l.start_ms, l.text
+		FROM song_synced_lyrics l
+		JOIN songs s ON s.id = l.song_id AND s.deleted_at IS NULL
+		WHERE l.song_id = $1 AND l.start_ms <= $2
+		ORDER BY l.start_ms DESC
+		LIMIT 1)
+		UNION ALL
+		(SELECT l.song_id, l.line_number, l.start_ms, l.text
+		FROM song_synced_lyrics l
+		JOIN songs s ON s.id = l.song_id AND s.deleted_at IS NULL
+		WHERE l.song_id = $1 AND l.start_ms > $2
+		ORDER BY l.start_ms
+		LIMIT 1)
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	rows, err := r.db.QueryContext(ctx, query, songID, position)
+	if err != nil {
+		return nil, nil, fmt.Errorf("SyncedLyricsRepo/GetLinesAround: error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	var current, next *models.SyncedLine
+	for rows.Next() {
+		var line models.SyncedLine
+		if err := rows.Scan(&line.SongID, &line.Number, &line.StartMs, &line.Text); err != nil {
+			return nil, nil, fmt.Errorf("SyncedLyricsRepo/GetLinesAround: error scanning row: %w", err)
+		}
+		if line.StartMs <= position {
+			current = &line
+		} else {
+			next = &line
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("SyncedLyricsRepo/GetLinesAround: error iterating rows: %w", err)
+	}
+
+	return current, next, nil
+}
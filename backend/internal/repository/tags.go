@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salmon822/test_task/internal/pkg/logger"
+	"github.com/salmon822/test_task/internal/repository/models"
+)
+
+type TagsRepository struct {
+	db     sqlx.ExtContext
+	logger logger.Logger
+}
+
+func NewTagsRepository(
+	db *sqlx.DB,
+	logger logger.Logger,
+) Tags {
+	return &TagsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *TagsRepository) WithTX(tx *sqlx.Tx) Tags {
+	return &TagsRepository{
+		db:     tx,
+		logger: r.logger,
+	}
+}
+
+// GetOrCreate returns the tag of the same kind whose normalized name matches
+// the given one, creating it when there is none yet.
+func (r *TagsRepository) GetOrCreate(ctx context.Context, tag *models.Tag) (*models.Tag, error) {
+	query := `
+		INSERT INTO tags (id, name, kind)
+		VALUES (default, $1, $2)
+		ON CONFLICT (kind, (lower(regexp_replace(btrim(name), '\s+', ' ', 'g')))) DO UPDATE SET name = tags.name
+		RETURNING id, name, kind
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	var res models.Tag
+	row := r.db.QueryRowxContext(ctx, query, tag.Name, tag.Kind)
+	if err := row.Scan(&res.ID, &res.Name, &res.Kind); err != nil {
+		return nil, fmt.Errorf("TagsRepo/GetOrCreate: error: %w", classifyError(err))
+	}
+
+	return &res, nil
+}
+
+func (r *TagsRepository) GetSongTags(ctx context.Context, songID int64) ([]*models.Tag, error) {
+	query := `
+		SELECT t.id, t.name, t.kind
+		FROM song_tags st
+		JOIN tags t ON t.id = st.tag_id
+		JOIN songs s ON s.id = st.song_id AND s.deleted_at IS NULL
+		WHERE st.song_id = $1
+		ORDER BY t.kind, t.name
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	rows, err := r.db.QueryContext(ctx, query, songID)
+	if err != nil {
+		return nil, fmt.Errorf("TagsRepo/GetSongTags: error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []*models.Tag{}
+	for rows.Next() {
+		var tag models.Tag
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.Kind); err != nil {
+			return nil, fmt.Errorf("TagsRepo/GetSongTags: error scanning row: %w", err)
+		}
+		tags = append(tags, &tag)
+	}
+
+	return tags, nil
+}
+
+func (r *TagsRepository) AddSongTag(ctx context.Context, songID int64, tagID int64) error {
+	query := `
+		INSERT INTO song_tags (song_id, tag_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	_, err := r.db.ExecContext(ctx, query, songID, tagID)
+	if err != nil {
+		return fmt.Errorf("TagsRepo/AddSongTag: error: %w", classifyError(err))
+	}
+
+	return nil
+}
+
+func (r *TagsRepository) RemoveSongTag(ctx context.Context, songID int64, tagID int64) error {
+	query := `
+		DELETE FROM song_tags
+		WHERE song_id = $1 AND tag_id = $2
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	_, err := r.db.ExecContext(ctx, query, songID, tagID)
+	if err != nil {
+		return fmt.Errorf("TagsRepo/RemoveSongTag: error: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TagsRepository) RemoveSongTags(ctx context.Context, songID int64) error {
+	query := `
+		DELETE FROM song_tags
+		WHERE song_id = $1
+	`
+
+	r.logger.Debugf("SQL Query: %s", query)
+
+	_, err := r.db.ExecContext(ctx, query, songID)
+	if err != nil {
+		return fmt.Errorf("TagsRepo/RemoveSongTags: error: %w", err)
+	}
+
+	return nil
+}
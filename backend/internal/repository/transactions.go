@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -17,3 +18,26 @@ func NewTransactionsRepo(db *sqlx.DB) *TransactionsRepo {
 func (r *TransactionsRepo) StartTransaction(ctx context.Context) (*sqlx.Tx, error) {
 	return r.db.Beginx()
 }
+
+// Savepoint marks a point of the transaction that it can be rolled back to
+// without losing the work done before it. The name must be an identifier.
+func (r *TransactionsRepo) Savepoint(ctx context.Context, tx *sqlx.Tx, name string) error {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("TransactionsRepo/Savepoint: error: %w", err)
+	}
+	return nil
+}
+
+func (r *TransactionsRepo) RollbackToSavepoint(ctx context.Context, tx *sqlx.Tx, name string) error {
+	if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("TransactionsRepo/RollbackToSavepoint: error: %w", err)
+	}
+	return nil
+}
+
+func (r *TransactionsRepo) ReleaseSavepoint(ctx context.Context, tx *sqlx.Tx, name string) error {
+	if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("TransactionsRepo/ReleaseSavepoint: error: %w", err)
+	}
+	return nil
+}
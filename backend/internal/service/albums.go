@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/pkg/logger"
+	"github.com/salmon822/test_task/internal/repository"
+	"github.com/salmon822/test_task/internal/service/converters"
+)
+
+type AlbumsService struct {
+	transactionRepo repository.Transactions
+	albumsRepo      repository.Albums
+	artistsRepo     repository.Artists
+	revisionsRepo   repository.SongRevisions
+	logger          logger.Logger
+}
+
+func NewAlbumsService(
+	transactionRepo repository.Transactions,
+	albumsRepo repository.Albums,
+	artistsRepo repository.Artists,
+	revisionsRepo repository.SongRevisions,
+	logger logger.Logger,
+) Albums {
+	return &AlbumsService{
+		transactionRepo: transactionRepo,
+		albumsRepo:      albumsRepo,
+		artistsRepo:     artistsRepo,
+		revisionsRepo:   revisionsRepo,
+		logger:          logger,
+	}
+}
+
+func (s *AlbumsService) CreateAlbum(ctx context.Context, album *domain.Album) (*domain.Album, error) {
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	artist, err := getOrCreateArtist(ctx, s.artistsRepo.WithTX(tx), album.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	album.ArtistID = artist.ID
+	album.GroupName = artist.Name
+	album.CreatedAt = now
+	album.UpdatedAt = now
+
+	albumModel, err := s.albumsRepo.WithTX(tx).Create(ctx, converters.AlbumDomain2Models(album))
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	s.logger.Infof("Album created successfully with ID: %d", albumModel.ID)
+
+	return converters.AlbumModels2Domain(albumModel), nil
+}
+
+func (s *AlbumsService) GetAlbum(ctx context.Context, id int64) (*domain.Album, error) {
+	album, err := s.albumsRepo.GetById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return converters.AlbumModels2Domain(album), nil
+}
+
+func (s *AlbumsService) GetAlbumTracks(ctx context.Context, id int64) (*domain.AlbumTracks, error) {
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	tracks, err := s.getAlbumTracks(ctx, s.albumsRepo.WithTX(tx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	s.logger.Infof("Tracks retrieved successfully for album ID %d", id)
+
+	return tracks, nil
+}
+
+// ReorderAlbumTracks moves the tracks of the album to the given positions and
+// records an update revision for each of the moved songs.
+func (s *AlbumsService) ReorderAlbumTracks(ctx context.Context, id int64, positions []*domain.TrackPosition) (*domain.AlbumTracks, error) {
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	albumsRepo := s.albumsRepo.WithTX(tx)
+
+	// Look the album up first, so that a missing album is reported as such
+	// rather than as songs that are not its tracks.
+	if _, err := albumsRepo.GetById(ctx, id); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	err = albumsRepo.SetTrackPositions(ctx, id, domain.MapSlice(positions, converters.TrackPositionDomain2Models), time.Now().Unix())
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	tracks, err := s.getAlbumTracks(ctx, albumsRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	moved := make(map[int64]struct{}, len(positions))
+	for _, position := range positions {
+		moved[position.SongID] = struct{}{}
+	}
+	revisionsRepo := s.revisionsRepo.WithTX(tx)
+	for _, track := range tracks.Tracks {
+		if _, ok := moved[track.ID]; !ok {
+			continue
+		}
+		if err := saveSongRevision(ctx, revisionsRepo, s.logger, domain.SongRevisionUpdate, track); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	s.logger.Infof("Tracks reordered successfully for album ID %d", id)
+
+	return tracks, nil
+}
+
+func (s *AlbumsService) getAlbumTracks(ctx context.Context, albumsRepo repository.Albums, id int64) (*domain.AlbumTracks, error) {
+	album, err := albumsRepo.GetById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	tracks, err := albumsRepo.GetTracks(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return &domain.AlbumTracks{
+		Album:  *converters.AlbumModels2Domain(album),
+		Tracks: domain.MapSlice(tracks, converters.SongModels2Domain),
+	}, nil
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/pkg/logger"
+	"github.com/salmon822/test_task/internal/repository"
+	"github.com/salmon822/test_task/internal/repository/models"
+	"github.com/salmon822/test_task/internal/service/converters"
+)
+
+type ArtistsService struct {
+	transactionRepo repository.Transactions
+	artistsRepo     repository.Artists
+	logger          logger.Logger
+}
+
+func NewArtistsService(
+	transactionRepo repository.Transactions,
+	artistsRepo repository.Artists,
+	logger logger.Logger,
+) Artists {
+	return &ArtistsService{
+		transactionRepo: transactionRepo,
+		artistsRepo:     artistsRepo,
+		logger:          logger,
+	}
+}
+
+// normalizeName trims the name and collapses inner whitespace so that
+// "Muse" and "muse " resolve to the same artist or tag.
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+func getOrCreateArtist(ctx context.Context, artistsRepo repository.Artists, name string) (*domain.Artist, error) {
+	now := time.Now().Unix()
+
+	artist, err := artistsRepo.GetOrCreate(ctx, &models.Artist{
+		Name:      normalizeName(name),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return converters.ArtistModels2Domain(artist), nil
+}
+
+func (s *ArtistsService) CreateArtist(ctx context.Context, artist *domain.Artist) (*domain.Artist, error) {
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().Unix()
+	artist.Name = normalizeName(artist.Name)
+	artist.CreatedAt = now
+	artist.UpdatedAt = now
+
+	artistModel, err := s.artistsRepo.WithTX(tx).Create(ctx, converters.ArtistDomain2Models(artist))
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	s.logger.Infof("Artist created successfully with ID: %d", artistModel.ID)
+
+	return converters.ArtistModels2Domain(artistModel), nil
+}
+
+func (s *ArtistsService) GetArtist(ctx context.Context, id int64) (*domain.Artist, error) {
+	artist, err := s.artistsRepo.GetById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return converters.ArtistModels2Domain(artist), nil
+}
+
+func (s *ArtistsService) UpdateArtist(ctx context.Context, id int64, artistData *domain.Artist) (*domain.Artist, error) {
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	artist, err := s.artistsRepo.WithTX(tx).GetById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	artist.Name = normalizeName(artistData.Name)
+	artist.UpdatedAt = time.Now().Unix()
+
+	updated, err := s.artistsRepo.WithTX(tx).Update(ctx, artist)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	s.logger.Infof("Artist with ID %d updated successfully", id)
+
+	return converters.ArtistModels2Domain(updated), nil
+}
+
+func (s *ArtistsService) DeleteArtist(ctx context.Context, id int64) error {
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := s.artistsRepo.WithTX(tx).Delete(ctx, id); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	s.logger.Infof("Artist with ID %d deleted successfully", id)
+
+	return nil
+}
+
+func (s *ArtistsService) GetArtists(ctx context.Context, filters *domain.ArtistFilters, page int64, pageSize int64) ([]*domain.Artist, error) {
+	artists, err := s.artistsRepo.GetArtists(ctx, converters.ArtistFiltersDomain2Models(filters), page, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	s.logger.Infof("Artists retrieved successfully")
+
+	return domain.MapSlice(artists, converters.ArtistModels2Domain), nil
+}
@@ -0,0 +1,50 @@
+package converters
+
+import (
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/repository/models"
+)
+
+func AlbumDomain2Models(a *domain.Album) *models.Album {
+	if a == nil {
+		return nil
+	}
+	album := &models.Album{
+		ID:          a.ID,
+		ArtistID:    a.ArtistID,
+		GroupName:   a.GroupName,
+		Title:       a.Title,
+		ReleaseDate: dateDomain2Models(a.ReleaseDate),
+		CoverLink:   a.CoverLink,
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+	}
+
+	return album
+}
+
+func AlbumModels2Domain(a *models.Album) *domain.Album {
+	if a == nil {
+		return nil
+	}
+	album := &domain.Album{
+		ID:          a.ID,
+		ArtistID:    a.ArtistID,
+		GroupName:   a.GroupName,
+		Title:       a.Title,
+		ReleaseDate: dateModels2Domain(a.ReleaseDate),
+		CoverLink:   a.CoverLink,
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+	}
+
+	return album
+}
+
+func TrackPositionDomain2Models(p *domain.TrackPosition) *models.TrackPosition {
+	return &models.TrackPosition{
+		SongID:      p.SongID,
+		DiscNumber:  p.DiscNumber,
+		TrackNumber: p.TrackNumber,
+	}
+}
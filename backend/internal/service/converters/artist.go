@@ -0,0 +1,40 @@
+package converters
+
+import (
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/repository/models"
+)
+
+func ArtistDomain2Models(a *domain.Artist) *models.Artist {
+	if a == nil {
+		return nil
+	}
+	artist := &models.Artist{
+		ID:        a.ID,
+		Name:      a.Name,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+
+	return artist
+}
+
+func ArtistModels2Domain(a *models.Artist) *domain.Artist {
+	if a == nil {
+		return nil
+	}
+	artist := &domain.Artist{
+		ID:        a.ID,
+		Name:      a.Name,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+
+	return artist
+}
+
+func ArtistFiltersDomain2Models(a *domain.ArtistFilters) *models.ArtistFilters {
+	return &models.ArtistFilters{
+		Name: a.Name,
+	}
+}
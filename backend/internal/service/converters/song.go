@@ -1,6 +1,9 @@
 package converters
 
 import (
+	"database/sql"
+	"time"
+
 	"github.com/salmon822/test_task/internal/domain"
 	"github.com/salmon822/test_task/internal/repository/models"
 )
@@ -11,13 +14,27 @@ func SongDomain2Models(s *domain.Song) *models.Song {
 	}
 	song := &models.Song{
 		ID:          s.ID,
+		ArtistID:    s.ArtistID,
 		GroupName:   s.GroupName,
 		SongTitle:   s.SongTitle,
-		ReleaseDate: s.ReleaseDate,
+		ReleaseDate: dateDomain2Models(s.ReleaseDate),
 		SongText:    s.SongText,
+		Stanzas:     StanzasDomain2Models(s.Stanzas),
 		Link:        s.Link,
 		CreatedAt:   s.CreatedAt,
 		UpdatedAt:   s.UpdatedAt,
+		DeletedAt:   s.DeletedAt,
+		Version:     s.Version,
+
+		EnrichmentStatus:    s.EnrichmentStatus,
+		EnrichmentAttempts:  s.EnrichmentAttempts,
+		EnrichmentLastError: s.EnrichmentLastError,
+
+		AlbumID:     s.AlbumID,
+		DiscNumber:  s.DiscNumber,
+		TrackNumber: s.TrackNumber,
+
+		Score: s.Score,
 	}
 
 	return song
@@ -29,39 +46,167 @@ func SongModels2Domain(s *models.Song) *domain.Song {
 	}
 	song := &domain.Song{
 		ID:          s.ID,
+		ArtistID:    s.ArtistID,
 		GroupName:   s.GroupName,
 		SongTitle:   s.SongTitle,
-		ReleaseDate: s.ReleaseDate,
+		ReleaseDate: dateModels2Domain(s.ReleaseDate),
 		SongText:    s.SongText,
 		Link:        s.Link,
 		CreatedAt:   s.CreatedAt,
 		UpdatedAt:   s.UpdatedAt,
+		DeletedAt:   s.DeletedAt,
+		Version:     s.Version,
+
+		EnrichmentStatus:    s.EnrichmentStatus,
+		EnrichmentAttempts:  s.EnrichmentAttempts,
+		EnrichmentLastError: s.EnrichmentLastError,
+
+		AlbumID:     s.AlbumID,
+		DiscNumber:  s.DiscNumber,
+		TrackNumber: s.TrackNumber,
+
+		Score: s.Score,
 	}
 
 	return song
 }
-func SongModels2DomainSongDetails(s *models.SongWithVerses) *domain.SongWithVerses {
-	return &domain.SongWithVerses{
-		Song:        *SongModels2Domain(&s.Song),
-		TotalVerses: s.TotalVerses,
-		Page:        s.Page,
-		PageSize:    s.PageSize,
-		Verses:      s.Verses,
+func StanzasDomain2Models(s []*domain.Stanza) []models.Stanza {
+	if s == nil {
+		return nil
+	}
+	stanzas := make([]models.Stanza, 0, len(s))
+	for _, stanza := range s {
+		stanzas = append(stanzas, models.Stanza{
+			Section: stanza.Section,
+			Label:   stanza.Label,
+			Lines:   stanza.Lines,
+		})
+	}
+
+	return stanzas
+}
+
+// StanzasModels2Domain numbers the stored stanzas in the order they are kept.
+func StanzasModels2Domain(s []models.Stanza) []*domain.Stanza {
+	if s == nil {
+		return nil
 	}
+	stanzas := make([]*domain.Stanza, 0, len(s))
+	for i, stanza := range s {
+		stanzas = append(stanzas, &domain.Stanza{
+			Number:  int64(i + 1),
+			Section: stanza.Section,
+			Label:   stanza.Label,
+			Lines:   stanza.Lines,
+		})
+	}
+
+	return stanzas
 }
 
 func SongFiltersModels2Domain(s *models.SongFilters) *domain.SongFilters {
 	return &domain.SongFilters{
-		GroupName:   s.GroupName,
-		SongTitle:   s.SongTitle,
-		ReleaseDate: s.ReleaseDate,
+		GroupName:    s.GroupName,
+		ArtistID:     s.ArtistID,
+		AlbumID:      s.AlbumID,
+		SongTitle:    s.SongTitle,
+		ReleaseDate:  s.ReleaseDate,
+		ReleasedFrom: s.ReleasedFrom,
+		ReleasedTo:   s.ReleasedTo,
+		Tags:         s.Tags,
+		TagsMatch:    s.TagsMatch,
+		Genre:        s.Genre,
+		Fuzzy:        s.Fuzzy,
+
+		IncludeDeleted: s.IncludeDeleted,
 	}
 }
 
 func SongFiltersDomain2Models(s *domain.SongFilters) *models.SongFilters {
 	return &models.SongFilters{
-		GroupName:   s.GroupName,
-		SongTitle:   s.SongTitle,
-		ReleaseDate: s.ReleaseDate,
+		GroupName:    s.GroupName,
+		ArtistID:     s.ArtistID,
+		AlbumID:      s.AlbumID,
+		SongTitle:    s.SongTitle,
+		ReleaseDate:  s.ReleaseDate,
+		ReleasedFrom: s.ReleasedFrom,
+		ReleasedTo:   s.ReleasedTo,
+		Tags:         s.Tags,
+		TagsMatch:    s.TagsMatch,
+		Genre:        s.Genre,
+		Fuzzy:        s.Fuzzy,
+
+		IncludeDeleted: s.IncludeDeleted,
+	}
+}
+
+func SongSearchDomain2Models(s *domain.SongSearch) *models.SongSearch {
+	return &models.SongSearch{
+		Query:    s.Query,
+		Language: s.Language,
+	}
+}
+
+func SongSearchResultModels2Domain(s *models.SongSearchResult) *domain.SongSearchResult {
+	return &domain.SongSearchResult{
+		Song:    *SongModels2Domain(&s.Song),
+		Rank:    s.Rank,
+		Snippet: s.Snippet,
+	}
+}
+
+func SongCursorDomain2Models(c *domain.SongCursor) *models.SongCursor {
+	if c == nil {
+		return nil
+	}
+	return &models.SongCursor{
+		Keys: c.Keys,
+		ID:   c.ID,
+	}
+}
+
+func SongSortDomain2Models(s domain.SongSort) models.SongSort {
+	return models.SongSort{
+		Field: s.Field,
+		Desc:  s.Desc,
+	}
+}
+
+func dateDomain2Models(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+func dateModels2Domain(t sql.NullTime) time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+	return t.Time
+}
+
+func SongLyricsDomain2Models(s *domain.SongLyrics) *models.SongLyrics {
+	if s == nil {
+		return nil
+	}
+	return &models.SongLyrics{
+		SongID:    s.SongID,
+		Language:  s.Language,
+		SongText:  s.SongText,
+		Stanzas:   StanzasDomain2Models(s.Stanzas),
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func SongLyricsModels2Domain(s *models.SongLyrics) *domain.SongLyrics {
+	if s == nil {
+		return nil
+	}
+	return &domain.SongLyrics{
+		SongID:    s.SongID,
+		Language:  s.Language,
+		SongText:  s.SongText,
+		Stanzas:   StanzasModels2Domain(s.Stanzas),
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
 	}
 }
@@ -0,0 +1,61 @@
+package converters
+
+import (
+	"time"
+
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/repository/models"
+)
+
+func SongSnapshotDomain2Models(s *domain.Song) models.SongSnapshot {
+	snapshot := models.SongSnapshot{
+		ArtistID:    s.ArtistID,
+		GroupName:   s.GroupName,
+		SongTitle:   s.SongTitle,
+		SongText:    s.SongText,
+		Link:        s.Link,
+		AlbumID:     s.AlbumID,
+		DiscNumber:  s.DiscNumber,
+		TrackNumber: s.TrackNumber,
+		CreatedAt:   s.CreatedAt,
+	}
+	if !s.ReleaseDate.IsZero() {
+		releaseDate := s.ReleaseDate
+		snapshot.ReleaseDate = &releaseDate
+	}
+
+	return snapshot
+}
+
+func SongSnapshotModels2Domain(songID int64, s models.SongSnapshot) *domain.Song {
+	song := &domain.Song{
+		ID:          songID,
+		ArtistID:    s.ArtistID,
+		GroupName:   s.GroupName,
+		SongTitle:   s.SongTitle,
+		SongText:    s.SongText,
+		Link:        s.Link,
+		AlbumID:     s.AlbumID,
+		DiscNumber:  s.DiscNumber,
+		TrackNumber: s.TrackNumber,
+		CreatedAt:   s.CreatedAt,
+	}
+	if s.ReleaseDate != nil {
+		song.ReleaseDate = s.ReleaseDate.In(time.UTC)
+	}
+
+	return song
+}
+
+func SongRevisionModels2Domain(r *models.SongRevision) *domain.SongRevision {
+	if r == nil {
+		return nil
+	}
+	return &domain.SongRevision{
+		SongID:    r.SongID,
+		Revision:  r.Revision,
+		Action:    r.Action,
+		Song:      *SongSnapshotModels2Domain(r.SongID, r.Snapshot),
+		CreatedAt: r.CreatedAt,
+	}
+}
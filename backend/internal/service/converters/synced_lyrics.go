@@ -0,0 +1,30 @@
+package converters
+
+import (
+	"time"
+
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/repository/models"
+)
+
+func SyncedLineDomain2Models(l *domain.SyncedLine) *models.SyncedLine {
+	if l == nil {
+		return nil
+	}
+	return &models.SyncedLine{
+		Number:  l.Number,
+		StartMs: l.Start.Milliseconds(),
+		Text:    l.Text,
+	}
+}
+
+func SyncedLineModels2Domain(l *models.SyncedLine) *domain.SyncedLine {
+	if l == nil {
+		return nil
+	}
+	return &domain.SyncedLine{
+		Number: l.Number,
+		Start:  time.Duration(l.StartMs) * time.Millisecond,
+		Text:   l.Text,
+	}
+}
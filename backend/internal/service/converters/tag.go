@@ -0,0 +1,28 @@
+package converters
+
+import (
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/repository/models"
+)
+
+func TagDomain2Models(t *domain.Tag) *models.Tag {
+	if t == nil {
+		return nil
+	}
+	return &models.Tag{
+		ID:   t.ID,
+		Name: t.Name,
+		Kind: t.Kind,
+	}
+}
+
+func TagModels2Domain(t *models.Tag) *domain.Tag {
+	if t == nil {
+		return nil
+	}
+	return &domain.Tag{
+		ID:   t.ID,
+		Name: t.Name,
+		Kind: t.Kind,
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/salmon822/test_task/internal/config"
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/musicinfo"
+	"github.com/salmon822/test_task/internal/pkg/logger"
+	"github.com/salmon822/test_task/internal/repository"
+	"github.com/salmon822/test_task/internal/repository/models"
+	"github.com/salmon822/test_task/internal/service/converters"
+)
+
+type EnrichmentService struct {
+	transactionRepo repository.Transactions
+	songsRepo       repository.Songs
+	revisionsRepo   repository.SongRevisions
+	musicInfo       musicinfo.Client
+	cfg             *config.EnrichmentWorkerConfig
+	logger          logger.Logger
+}
+
+func NewEnrichmentService(
+	transactionRepo repository.Transactions,
+	songsRepo repository.Songs,
+	revisionsRepo repository.SongRevisions,
+	musicInfo musicinfo.Client,
+	cfg *config.EnrichmentWorkerConfig,
+	logger logger.Logger,
+) Enrichment {
+	return &EnrichmentService{
+		transactionRepo: transactionRepo,
+		songsRepo:       songsRepo,
+		revisionsRepo:   revisionsRepo,
+		musicInfo:       musicInfo,
+		cfg:             cfg,
+		logger:          logger,
+	}
+}
+
+// enrichmentClaimTimeout is how long the songs claimed by a worker are left to
+// it, it is well above the time a batch of calls to the music info service
+// may take.
+const enrichmentClaimTimeout = 15 * time.Minute
+
+func (s *EnrichmentService) EnrichPendingSongs(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	songs, err := s.songsRepo.ClaimSongsToEnrich(ctx, now.Unix(), now.Add(enrichmentClaimTimeout).Unix(), s.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("database error: %w", err)
+	}
+
+	enriched := 0
+	for _, songModel := range songs {
+		if err := ctx.Err(); err != nil {
+			return enriched, err
+		}
+
+		song := converters.SongModels2Domain(songModel)
+		song.EnrichmentAttempts++
+
+		if song.NeedsEnrichment() {
+			if err := enrichSong(ctx, s.musicInfo, song); err != nil {
+				if ctx.Err() != nil {
+					return enriched, ctx.Err()
+				}
+				if err := s.saveFailure(ctx, song, now, err); err != nil {
+					return enriched, err
+				}
+				continue
+			}
+			if song.EnrichmentLastError != "" {
+				s.logger.Warnf("Song with ID %d enriched without a release date: %s", song.ID, song.EnrichmentLastError)
+			}
+		} else {
+			song.EnrichmentLastError = ""
+		}
+		song.EnrichmentStatus = domain.SongEnrichmentEnriched
+		song.Stanzas = parseLyrics(song.SongText)
+		song.UpdatedAt = time.Now().Unix()
+
+		if err := s.saveEnrichment(ctx, song); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				s.logger.Infof("Song with ID %d was deleted while being enriched", song.ID)
+				continue
+			}
+			return enriched, err
+		}
+
+		s.logger.Infof("Song with ID %d enriched after %d attempts", song.ID, song.EnrichmentAttempts)
+		enriched++
+	}
+
+	return enriched, nil
+}
+
+// saveEnrichment stores the enriched song and records the result as an update
+// revision, like any other change of the song.
+func (s *EnrichmentService) saveEnrichment(ctx context.Context, song *domain.Song) error {
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	saved, err := s.songsRepo.WithTX(tx).SaveEnrichment(ctx, converters.SongDomain2Models(song))
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	err = saveSongRevision(ctx, s.revisionsRepo.WithTX(tx), s.logger, domain.SongRevisionUpdate, converters.SongModels2Domain(saved))
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *EnrichmentService) saveFailure(ctx context.Context, song *domain.Song, now time.Time, cause error) error {
+	status := domain.SongEnrichmentPending
+	if errors.Is(cause, musicinfo.ErrSongNotFound) || song.EnrichmentAttempts >= s.cfg.MaxAttempts {
+		status = domain.SongEnrichmentFailed
+	}
+
+	s.logger.Warnf("Failed to enrich song with ID %d (attempt %d, status %s): %v",
+		song.ID, song.EnrichmentAttempts, status, cause)
+
+	err := s.songsRepo.SaveEnrichmentFailure(ctx, &models.SongEnrichmentFailure{
+		ID:            song.ID,
+		Status:        status,
+		Attempts:      song.EnrichmentAttempts,
+		LastError:     cause.Error(),
+		NextAttemptAt: now.Add(s.backoff(song.EnrichmentAttempts)).Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	return nil
+}
+
+// backoff returns the delay before the next attempt: BaseBackoff doubled for
+// every failed attempt and capped at MaxBackoff.
+func (s *EnrichmentService) backoff(attempts int64) time.Duration {
+	delay := s.cfg.BaseBackoff
+	for i := int64(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= s.cfg.MaxBackoff {
+			return s.cfg.MaxBackoff
+		}
+	}
+
+	return delay
+}
+
+var musicInfoDateLayouts = []string{"02.01.2006", time.DateOnly}
+
+// enrichSong fills the fields missing from the song with the data provided by
+// the music info service. Fields sent by the client are never overwritten. A
+// release date that cannot be parsed is left unknown and kept as the last
+// enrichment error, the other fields are filled all the same.
+func enrichSong(ctx context.Context, client musicinfo.Client, song *domain.Song) error {
+	detail, err := client.GetSongDetail(ctx, song.GroupName, song.SongTitle)
+	if err != nil {
+		return err
+	}
+
+	song.EnrichmentLastError = ""
+	if song.ReleaseDate.IsZero() && detail.ReleaseDate != "" {
+		releaseDate, err := parseMusicInfoDate(detail.ReleaseDate)
+		if err != nil {
+			song.EnrichmentLastError = err.Error()
+		} else {
+			song.ReleaseDate = releaseDate
+		}
+	}
+	if song.SongText == "" {
+		song.SongText = detail.Text
+	}
+	if song.Link == "" {
+		song.Link = detail.Link
+	}
+
+	song.EnrichmentStatus = domain.SongEnrichmentEnriched
+
+	return nil
+}
+
+// parseMusicInfoDate parses the release date returned by the music info
+// service.
+func parseMusicInfoDate(value string) (time.Time, error) {
+	for _, layout := range musicInfoDateLayouts {
+		date, err := time.Parse(layout, value)
+		if err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("parseMusicInfoDate: unsupported release date format %q", value)
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/salmon822/test_task/internal/domain"
+	"golang.org/x/text/language"
+)
+
+var (
+	sectionMarkerRegexp = regexp.MustCompile(`^\[([^\[\]]+)\]$`)
+	sectionRepeatRegexp = regexp.MustCompile(`^[x×]\d+$`)
+)
+
+// parseLyrics splits the song text into stanzas on blank lines. A line like
+// "[Chorus]" or "[Verse 2]" starts a new stanza labelled with it. A labelled
+// stanza without lines repeats the last stanza with the same label.
+func parseLyrics(text string) []*domain.Stanza {
+	var (
+		stanzas []*domain.Stanza
+		current *domain.Stanza
+	)
+
+	previous := func(label string) *domain.Stanza {
+		for i := len(stanzas) - 1; i >= 0; i-- {
+			if strings.EqualFold(stanzas[i].Label, label) {
+				return stanzas[i]
+			}
+		}
+		return nil
+	}
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		if len(current.Lines) == 0 {
+			if repeated := previous(current.Label); current.Label != "" && repeated != nil {
+				current.Lines = repeated.Lines
+			}
+		}
+		current.Number = int64(len(stanzas) + 1)
+		stanzas = append(stanzas, current)
+		current = nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" {
+			// A marker on its own line may be separated from its lines by a
+			// blank line, unless it repeats an earlier section.
+			if current != nil && len(current.Lines) == 0 && previous(current.Label) == nil {
+				continue
+			}
+			flush()
+			continue
+		}
+
+		if match := sectionMarkerRegexp.FindStringSubmatch(line); match != nil {
+			flush()
+			label := strings.TrimSpace(match[1])
+			current = &domain.Stanza{
+				Section: sectionKind(label),
+				Label:   label,
+			}
+			continue
+		}
+
+		if current == nil {
+			current = &domain.Stanza{}
+		}
+		current.Lines = append(current.Lines, line)
+	}
+	flush()
+
+	return stanzas
+}
+
+// sectionKind normalizes a section label: "Verse 2: Eminem" and "Chorus x2"
+// become "verse" and "chorus".
+func sectionKind(label string) string {
+	label, _, _ = strings.Cut(label, ":")
+
+	var words []string
+	for _, word := range strings.Fields(strings.ToLower(label)) {
+		if strings.Trim(word, "0123456789") == "" || sectionRepeatRegexp.MatchString(word) {
+			continue
+		}
+		words = append(words, word)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// lyricsLines returns the lines of all stanzas in order.
+func lyricsLines(stanzas []*domain.Stanza) []string {
+	var lines []string
+	for _, stanza := range stanzas {
+		lines = append(lines, stanza.Lines...)
+	}
+
+	return lines
+}
+
+// matchLanguage returns the available language that best serves the preferred
+// ones, or "" when none of them is understood.
+func matchLanguage(preferred []string, available []string) string {
+	var desired []language.Tag
+	for _, value := range preferred {
+		if tag, err := language.Parse(value); err == nil {
+			desired = append(desired, tag)
+		}
+	}
+
+	var (
+		supported []language.Tag
+		languages []string
+	)
+	for _, value := range available {
+		if tag, err := language.Parse(value); err == nil {
+			supported = append(supported, tag)
+			languages = append(languages, value)
+		}
+	}
+
+	if len(desired) == 0 || len(supported) == 0 {
+		return ""
+	}
+
+	_, index, confidence := language.NewMatcher(supported).Match(desired...)
+	if confidence == language.No {
+		return ""
+	}
+
+	return languages[index]
+}
@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"time"
 
+	"github.com/salmon822/test_task/internal/config"
 	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/musicinfo"
 	"github.com/salmon822/test_task/internal/pkg/logger"
 	"github.com/salmon822/test_task/internal/repository"
 )
@@ -11,29 +14,104 @@ import (
 type Songs interface {
 	CreateSong(ctx context.Context, song *domain.Song) (*domain.Song, error)
 	DeleteSong(ctx context.Context, id int64) error
-	UpdateSong(ctx context.Context, id int64, songData *domain.Song) (*domain.Song, error)
-	GetSongTextByID(ctx context.Context, id, page, pageSize int64) (*domain.SongWithVerses, error)
-	GetFilteredSongs(ctx context.Context, filters *domain.SongFilters, page int64, pageSize int64) ([]*domain.Song, error)
+	RestoreSong(ctx context.Context, id int64) (*domain.Song, error)
+	GetSong(ctx context.Context, id int64, withText bool) (*domain.Song, error)
+	UpdateSong(ctx context.Context, id int64, songData *domain.Song, match *domain.SongVersionMatch) (*domain.Song, error)
+	PatchSong(ctx context.Context, id int64, patcher domain.SongPatcher, match *domain.SongVersionMatch) (*domain.Song, error)
+	GetSongTextByID(ctx context.Context, id int64, pagination *domain.LyricsPagination) (*domain.SongWithVerses, error)
+	GetFilteredSongs(ctx context.Context, filters *domain.SongFilters, pagination *domain.SongPagination) (*domain.SongList, error)
+	SearchSongs(ctx context.Context, search *domain.SongSearch, page int64, pageSize int64) ([]*domain.SongSearchResult, error)
+	GetSongRevisions(ctx context.Context, id int64) ([]*domain.SongRevision, error)
+	RestoreSongRevision(ctx context.Context, id int64, revision int64) (*domain.Song, error)
+	ImportSongs(ctx context.Context, rows domain.SongImportRows, options domain.SongImportOptions) (*domain.SongImportReport, error)
+	ApplySongBatch(ctx context.Context, mode string, operations []*domain.SongBatchOperation) (*domain.SongBatchReport, error)
+	ExportSongs(ctx context.Context, filters *domain.SongFilters, sort []domain.SongSort, fn func(*domain.Song) error) error
+}
+
+type Artists interface {
+	CreateArtist(ctx context.Context, artist *domain.Artist) (*domain.Artist, error)
+	GetArtist(ctx context.Context, id int64) (*domain.Artist, error)
+	UpdateArtist(ctx context.Context, id int64, artistData *domain.Artist) (*domain.Artist, error)
+	DeleteArtist(ctx context.Context, id int64) error
+	GetArtists(ctx context.Context, filters *domain.ArtistFilters, page int64, pageSize int64) ([]*domain.Artist, error)
+}
+
+type Albums interface {
+	CreateAlbum(ctx context.Context, album *domain.Album) (*domain.Album, error)
+	GetAlbum(ctx context.Context, id int64) (*domain.Album, error)
+	GetAlbumTracks(ctx context.Context, id int64) (*domain.AlbumTracks, error)
+	ReorderAlbumTracks(ctx context.Context, id int64, positions []*domain.TrackPosition) (*domain.AlbumTracks, error)
+}
+
+type Tags interface {
+	GetSongTags(ctx context.Context, songID int64) ([]*domain.Tag, error)
+	AddSongTags(ctx context.Context, songID int64, tags []*domain.Tag) ([]*domain.Tag, error)
+	ReplaceSongTags(ctx context.Context, songID int64, tags []*domain.Tag) ([]*domain.Tag, error)
+	RemoveSongTag(ctx context.Context, songID int64, tagID int64) error
+}
+
+type SongLyrics interface {
+	GetSongTranslations(ctx context.Context, songID int64) ([]*domain.SongLyrics, error)
+	GetSongTranslation(ctx context.Context, songID int64, language string) (*domain.SongLyrics, error)
+	PutSongTranslation(ctx context.Context, songLyrics *domain.SongLyrics) (*domain.SongLyrics, error)
+	DeleteSongTranslation(ctx context.Context, songID int64, language string) (*domain.SongLyrics, error)
+}
+
+type SyncedLyrics interface {
+	ReplaceSyncedLyrics(ctx context.Context, songID int64, lines []*domain.SyncedLine) (*domain.SyncedLyrics, error)
+	GetSyncedLyrics(ctx context.Context, songID int64) (*domain.SyncedLyrics, error)
+	GetSyncedLyricsAt(ctx context.Context, songID int64, position time.Duration) (*domain.SyncedLyricsPosition, error)
+}
+
+type Enrichment interface {
+	EnrichPendingSongs(ctx context.Context) (int, error)
+}
+
+type Trash interface {
+	PurgeDeletedSongs(ctx context.Context) (int64, error)
 }
 
 type Service struct {
 	Songs
+	Artists
+	Albums
+	Tags
+	SongLyrics
+	SyncedLyrics
+	Enrichment
+	Trash
 	logger logger.Logger
 }
 
 func NewService(
 	ctx context.Context,
+	cfg *config.Config,
 	repo *repository.Repository,
+	musicInfo musicinfo.Client,
 	logger logger.Logger,
 ) (Service, error) {
 
 	var (
-		songs = NewSongsService(repo.Transactions, repo.Songs, logger)
+		songs        = NewSongsService(repo.Transactions, repo.Songs, repo.Artists, repo.SongLyrics, repo.SongRevisions, musicInfo, logger)
+		artists      = NewArtistsService(repo.Transactions, repo.Artists, logger)
+		albums       = NewAlbumsService(repo.Transactions, repo.Albums, repo.Artists, repo.SongRevisions, logger)
+		tags         = NewTagsService(repo.Transactions, repo.Tags, repo.Songs, logger)
+		songLyrics   = NewSongLyricsService(repo.Transactions, repo.SongLyrics, repo.Songs, logger)
+		syncedLyrics = NewSyncedLyricsService(repo.Transactions, repo.SyncedLyrics, repo.Songs, logger)
+		enrichment   = NewEnrichmentService(repo.Transactions, repo.Songs, repo.SongRevisions, musicInfo, cfg.EnrichmentWorker, logger)
+		trash        = NewTrashService(repo.Songs, cfg.TrashPurgeWorker, logger)
 	)
 
 	res := Service{
-		Songs:  songs,
-		logger: logger,
+		Songs:        songs,
+		Artists:      artists,
+		Albums:       albums,
+		Tags:         tags,
+		SongLyrics:   songLyrics,
+		SyncedLyrics: syncedLyrics,
+		Enrichment:   enrichment,
+		Trash:        trash,
+		logger:       logger,
 	}
 
 	return res, nil
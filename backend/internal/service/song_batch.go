@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salmon822/test_task/internal/domain"
+)
+
+// ApplySongBatch applies the operations in order. Atomic batches run in one
+// transaction and are rolled back when an operation fails, independent ones
+// run every operation in its own transaction. Failing operations are
+// reported in their result rather than returned. New songs are not enriched
+// on the spot, as calling the music info service for each of them would not
+// fit in the request, they are left pending for the enrichment worker.
+func (s *SongsService) ApplySongBatch(ctx context.Context, mode string, operations []*domain.SongBatchOperation) (*domain.SongBatchReport, error) {
+	report := &domain.SongBatchReport{Mode: mode, Results: make([]*domain.SongBatchResult, 0, len(operations))}
+	for _, operation := range operations {
+		report.Results = append(report.Results, &domain.SongBatchResult{Op: operation.Op})
+	}
+
+	if mode == domain.SongBatchIndependent {
+		for i, operation := range operations {
+			result := report.Results[i]
+			if operation.Err != nil {
+				failSongBatchResult(report, result, operation.Err)
+				continue
+			}
+			song, err := s.applyIndependentSongOperation(ctx, operation)
+			if err != nil {
+				failSongBatchResult(report, result, err)
+				continue
+			}
+			succeedSongBatchResult(report, result, operation, song)
+		}
+		report.Committed = report.Succeeded > 0
+
+		s.logger.Infof("Song batch applied: %d operations succeeded, %d failed", report.Succeeded, report.Failed)
+
+		return report, nil
+	}
+
+	// Nothing is applied when an operation is not valid.
+	for i, operation := range operations {
+		if operation.Err != nil {
+			failSongBatchResult(report, report.Results[i], operation.Err)
+		}
+	}
+	if report.Failed > 0 {
+		skipSongBatchResults(report)
+		s.logger.Warnf("Song batch refused, %d of %d operations are not valid", report.Failed, len(operations))
+		return report, nil
+	}
+
+	for _, operation := range operations {
+		if operation.Op == domain.SongBatchCreate {
+			prepareSong(operation.Song)
+		}
+	}
+
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i, operation := range operations {
+		song, err := s.applySongOperation(ctx, tx, operation)
+		if err != nil {
+			failSongBatchResult(report, report.Results[i], err)
+			skipSongBatchResults(report)
+			s.logger.Warnf("Song batch rolled back, operation %d failed: %v", i, err)
+			return report, nil
+		}
+		succeedSongBatchResult(report, report.Results[i], operation, song)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	report.Committed = true
+
+	s.logger.Infof("Song batch committed: %d operations", report.Succeeded)
+
+	return report, nil
+}
+
+// applySongOperation applies the operation in the transaction.
+func (s *SongsService) applySongOperation(ctx context.Context, tx *sqlx.Tx, operation *domain.SongBatchOperation) (*domain.Song, error) {
+	switch operation.Op {
+	case domain.SongBatchCreate:
+		return s.createSong(ctx, tx, operation.Song)
+	case domain.SongBatchUpdate:
+		return s.updateSong(ctx, tx, operation.ID, operation.Song, operation.Match)
+	case domain.SongBatchDelete:
+		return nil, s.deleteSong(ctx, tx, operation.ID, operation.Match)
+	}
+
+	return nil, fmt.Errorf("%w: unknown operation %q", domain.ErrInvalidRequest, operation.Op)
+}
+
+// applyIndependentSongOperation applies the operation in a transaction of its
+// own.
+func (s *SongsService) applyIndependentSongOperation(ctx context.Context, operation *domain.SongBatchOperation) (*domain.Song, error) {
+	if operation.Op == domain.SongBatchCreate {
+		prepareSong(operation.Song)
+	}
+
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	song, err := s.applySongOperation(ctx, tx, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return song, nil
+}
+
+func failSongBatchResult(report *domain.SongBatchReport, result *domain.SongBatchResult, err error) {
+	result.Status, result.Err = domain.SongBatchFailed, err
+	report.Failed++
+}
+
+func succeedSongBatchResult(report *domain.SongBatchReport, result *domain.SongBatchResult, operation *domain.SongBatchOperation, song *domain.Song) {
+	result.Status, result.Song, result.SongID = domain.SongBatchSucceeded, song, operation.ID
+	if song != nil {
+		result.SongID = song.ID
+	}
+	report.Succeeded++
+}
+
+// skipSongBatchResults marks the operations of a failed atomic batch that did
+// not fail as skipped, those applied before the failure were rolled back.
+func skipSongBatchResults(report *domain.SongBatchReport) {
+	for _, result := range report.Results {
+		if result.Status != domain.SongBatchFailed {
+			result.Status, result.Song, result.SongID = domain.SongBatchSkipped, nil, 0
+		}
+	}
+	report.Succeeded = 0
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/repository/models"
+	"github.com/salmon822/test_task/internal/service/converters"
+)
+
+// songExportBatchSize is the number of songs fetched from the export cursor
+// at a time.
+const songExportBatchSize = 1000
+
+// ExportSongs passes every song matching the filters to fn, in the order of
+// the sort. The songs are read from a cursor in batches, so that the export
+// is not held in memory. An error returned by fn stops the export and is
+// returned as is.
+func (s *SongsService) ExportSongs(ctx context.Context, filters *domain.SongFilters, sort []domain.SongSort, fn func(*domain.Song) error) error {
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Fuzzy exports are ordered by relevance unless asked otherwise.
+	if len(sort) == 0 && filters.Fuzzy {
+		sort = []domain.SongSort{{Field: domain.SongSortScore, Desc: true}}
+	}
+
+	var exported int64
+	err = s.songsRepo.WithTX(tx).ExportSongs(ctx, converters.SongFiltersDomain2Models(filters),
+		domain.MapSlice(sort, converters.SongSortDomain2Models), songExportBatchSize,
+		func(songs []*models.Song) error {
+			for _, song := range songs {
+				if err := fn(converters.SongModels2Domain(song)); err != nil {
+					return err
+				}
+				exported++
+			}
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	s.logger.Infof("Exported %d songs", exported)
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/repository/models"
+	"github.com/salmon822/test_task/internal/service/converters"
+)
+
+const (
+	// songImportBatchSize is the number of rows inserted by one statement.
+	songImportBatchSize = 500
+	// songImportSavepoint is set before every batch, so that a batch with a
+	// failing row can be retried row by row.
+	songImportSavepoint = "song_import_batch"
+)
+
+// pendingImportRow is a valid row waiting for its batch to be inserted.
+type pendingImportRow struct {
+	result *domain.SongImportRowResult
+	song   *domain.Song
+}
+
+// songImport holds the state of an import running in a transaction.
+type songImport struct {
+	s       *SongsService
+	tx      *sqlx.Tx
+	report  *domain.SongImportReport
+	artists map[string]*domain.Artist
+	batch   []*pendingImportRow
+	now     int64
+}
+
+// ImportSongs reads the rows and inserts the valid ones in batches, in one
+// transaction. Atomic imports are rolled back when a row fails, best-effort
+// ones keep the rows that could be inserted. Songs missing details are saved
+// as pending and left to the enrichment worker.
+func (s *SongsService) ImportSongs(ctx context.Context, rows domain.SongImportRows, options domain.SongImportOptions) (*domain.SongImportReport, error) {
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	imp := &songImport{
+		s:       s,
+		tx:      tx,
+		report:  &domain.SongImportReport{Options: options},
+		artists: make(map[string]*domain.Artist),
+		now:     time.Now().Unix(),
+	}
+
+	for {
+		row, err := rows.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := imp.add(ctx, row); err != nil {
+			return nil, err
+		}
+		if len(imp.batch) >= songImportBatchSize {
+			if err := imp.flush(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := imp.flush(ctx); err != nil {
+		return nil, err
+	}
+
+	report := imp.report
+	if imp.aborted() {
+		for _, row := range report.Rows {
+			if row.Status == domain.SongImportRowImported {
+				row.Status, row.SongID = domain.SongImportRowSkipped, 0
+			}
+		}
+		report.Imported = 0
+		s.logger.Warnf("Song import rolled back, %d of %d rows failed", report.Failed, report.Total)
+		return report, nil
+	}
+	if options.DryRun {
+		for _, row := range report.Rows {
+			row.SongID = 0
+		}
+		s.logger.Infof("Song import dry run: %d rows would be imported, %d failed", report.Imported, report.Failed)
+		return report, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	report.Committed = true
+
+	s.logger.Infof("Song import committed: %d rows imported, %d failed", report.Imported, report.Failed)
+
+	return report, nil
+}
+
+// aborted reports whether nothing is to be saved any more, as an atomic
+// import has a failed row.
+func (imp *songImport) aborted() bool {
+	return imp.report.Options.Mode == domain.SongImportAtomic && imp.report.Failed > 0
+}
+
+func (imp *songImport) fail(result *domain.SongImportRowResult, err error) {
+	result.Status, result.Err = domain.SongImportRowFailed, err
+	imp.report.Failed++
+}
+
+// add prepares the row the way CreateSong does and queues it for the next
+// batch.
+func (imp *songImport) add(ctx context.Context, row *domain.SongImportRow) error {
+	result := &domain.SongImportRowResult{Line: row.Line}
+	imp.report.Rows = append(imp.report.Rows, result)
+	imp.report.Total++
+
+	if row.Err != nil {
+		imp.fail(result, row.Err)
+		return nil
+	}
+	if imp.aborted() {
+		result.Status = domain.SongImportRowSkipped
+		return nil
+	}
+
+	song := row.Song
+	prepareSong(song)
+	song.CreatedAt, song.UpdatedAt = imp.now, imp.now
+
+	artist, ok := imp.artists[song.GroupName]
+	if !ok {
+		var err error
+		artist, err = getOrCreateArtist(ctx, imp.s.artistsRepo.WithTX(imp.tx), song.GroupName)
+		if err != nil {
+			return err
+		}
+		imp.artists[song.GroupName] = artist
+	}
+	song.ArtistID, song.GroupName = artist.ID, artist.Name
+	song.Stanzas = parseLyrics(song.SongText)
+
+	imp.batch = append(imp.batch, &pendingImportRow{result: result, song: song})
+
+	return nil
+}
+
+// flush inserts the queued rows. When the batch is refused for its data, the
+// rows are inserted one by one to find the failing ones.
+func (imp *songImport) flush(ctx context.Context) error {
+	batch := imp.batch
+	imp.batch = nil
+	if len(batch) == 0 {
+		return nil
+	}
+	if imp.aborted() {
+		for _, row := range batch {
+			row.result.Status = domain.SongImportRowSkipped
+		}
+		return nil
+	}
+
+	err := imp.insert(ctx, batch)
+	if err == nil || !isDataError(err) {
+		return err
+	}
+
+	for _, row := range batch {
+		if imp.aborted() {
+			row.result.Status = domain.SongImportRowSkipped
+			continue
+		}
+		if err := imp.insert(ctx, []*pendingImportRow{row}); err != nil {
+			if !isDataError(err) {
+				return err
+			}
+			imp.fail(row.result, err)
+		}
+	}
+
+	return nil
+}
+
+// insert writes the songs and their create revisions, or nothing when it
+// fails.
+func (imp *songImport) insert(ctx context.Context, rows []*pendingImportRow) error {
+	transactionRepo := imp.s.transactionRepo
+	if err := transactionRepo.Savepoint(ctx, imp.tx, songImportSavepoint); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	err := imp.insertSongs(ctx, rows)
+	if err != nil {
+		if rollbackErr := transactionRepo.RollbackToSavepoint(ctx, imp.tx, songImportSavepoint); rollbackErr != nil {
+			return fmt.Errorf("database error: %w", rollbackErr)
+		}
+		return err
+	}
+
+	if err := transactionRepo.ReleaseSavepoint(ctx, imp.tx, songImportSavepoint); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	for _, row := range rows {
+		row.result.Status = domain.SongImportRowImported
+		row.result.SongID = row.song.ID
+		imp.report.Imported++
+	}
+
+	return nil
+}
+
+func (imp *songImport) insertSongs(ctx context.Context, rows []*pendingImportRow) error {
+	songModels := make([]*models.Song, 0, len(rows))
+	for _, row := range rows {
+		songModels = append(songModels, converters.SongDomain2Models(row.song))
+	}
+
+	created, err := imp.s.songsRepo.WithTX(imp.tx).CreateBatch(ctx, songModels)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	revisions := make([]*models.SongRevision, 0, len(created))
+	for i, songModel := range created {
+		song := converters.SongModels2Domain(songModel)
+		rows[i].song.ID, rows[i].song.Version = song.ID, song.Version
+		revisions = append(revisions, &models.SongRevision{
+			SongID:    song.ID,
+			Action:    domain.SongRevisionCreate,
+			Snapshot:  converters.SongSnapshotDomain2Models(song),
+			CreatedAt: imp.now,
+		})
+	}
+
+	if err := imp.s.revisionsRepo.WithTX(imp.tx).CreateBatch(ctx, revisions); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	return nil
+}
+
+// isDataError reports whether the database refused the write for the data it
+// was given, rather than failing.
+func isDataError(err error) bool {
+	return errors.Is(err, domain.ErrConflict) || errors.Is(err, domain.ErrValidation)
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/pkg/logger"
+	"github.com/salmon822/test_task/internal/repository"
+	"github.com/salmon822/test_task/internal/service/converters"
+)
+
+type SongLyricsService struct {
+	transactionRepo repository.Transactions
+	songLyricsRepo  repository.SongLyrics
+	songsRepo       repository.Songs
+	logger          logger.Logger
+}
+
+func NewSongLyricsService(
+	transactionRepo repository.Transactions,
+	songLyricsRepo repository.SongLyrics,
+	songsRepo repository.Songs,
+	logger logger.Logger,
+) SongLyrics {
+	return &SongLyricsService{
+		transactionRepo: transactionRepo,
+		songLyricsRepo:  songLyricsRepo,
+		songsRepo:       songsRepo,
+		logger:          logger,
+	}
+}
+
+func (s *SongLyricsService) GetSongTranslations(ctx context.Context, songID int64) ([]*domain.SongLyrics, error) {
+	if _, err := s.songsRepo.GetByIdWithoutText(ctx, songID); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	translations, err := s.songLyricsRepo.GetBySongID(ctx, songID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return domain.MapSlice(translations, converters.SongLyricsModels2Domain), nil
+}
+
+// GetSongTranslation returns the translation of the song to the language, or
+// nil when there is none.
+func (s *SongLyricsService) GetSongTranslation(ctx context.Context, songID int64, language string) (*domain.SongLyrics, error) {
+	translation, err := s.songLyricsRepo.Get(ctx, songID, language)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return converters.SongLyricsModels2Domain(translation), nil
+}
+
+// PutSongTranslation adds the translation of the song or replaces the one in
+// the same language.
+func (s *SongLyricsService) PutSongTranslation(ctx context.Context, songLyrics *domain.SongLyrics) (*domain.SongLyrics, error) {
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := s.songsRepo.WithTX(tx).GetById(ctx, songLyrics.SongID); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	now := time.Now().Unix()
+	songLyrics.CreatedAt = now
+	songLyrics.UpdatedAt = now
+	songLyrics.Stanzas = parseLyrics(songLyrics.SongText)
+
+	translation, err := s.songLyricsRepo.WithTX(tx).Upsert(ctx, converters.SongLyricsDomain2Models(songLyrics))
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	s.logger.Infof("Translation to %s saved for song with ID %d", songLyrics.Language, songLyrics.SongID)
+
+	return converters.SongLyricsModels2Domain(translation), nil
+}
+
+// DeleteSongTranslation removes the translation of the song to the language
+// and returns it, or nil when there was none.
+func (s *SongLyricsService) DeleteSongTranslation(ctx context.Context, songID int64, language string) (*domain.SongLyrics, error) {
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	songLyricsRepo := s.songLyricsRepo.WithTX(tx)
+
+	translation, err := songLyricsRepo.Get(ctx, songID, language)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if translation == nil {
+		return nil, nil
+	}
+
+	if err := songLyricsRepo.Delete(ctx, songID, language); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	s.logger.Infof("Translation to %s deleted from song with ID %d", language, songID)
+
+	return converters.SongLyricsModels2Domain(translation), nil
+}
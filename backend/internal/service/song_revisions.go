@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/pkg/logger"
+	"github.com/salmon822/test_task/internal/repository"
+	"github.com/salmon822/test_task/internal/repository/models"
+	"github.com/salmon822/test_task/internal/service/converters"
+)
+
+// saveRevision records the state of the song after the change as its next
+// revision, in the transaction of the change.
+func (s *SongsService) saveRevision(ctx context.Context, tx *sqlx.Tx, action string, song *domain.Song) error {
+	return saveSongRevision(ctx, s.revisionsRepo.WithTX(tx), s.logger, action, song)
+}
+
+// saveSongRevision records the state of the song after the change as its next
+// revision. revisionsRepo must run in the transaction of the change.
+func saveSongRevision(ctx context.Context, revisionsRepo repository.SongRevisions, logger logger.Logger, action string, song *domain.Song) error {
+	revision, err := revisionsRepo.Create(ctx, &models.SongRevision{
+		SongID:    song.ID,
+		Action:    action,
+		Snapshot:  converters.SongSnapshotDomain2Models(song),
+		CreatedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	logger.Debugf("Revision %d (%s) saved for song with ID %d", revision.Revision, action, song.ID)
+
+	return nil
+}
+
+// GetSongRevisions returns the revisions of the song, oldest first, with the
+// fields changed by each of them. Songs in the trash or purged from it keep
+// their revisions, only unknown songs are not found.
+func (s *SongsService) GetSongRevisions(ctx context.Context, id int64) ([]*domain.SongRevision, error) {
+	revisionModels, err := s.revisionsRepo.GetBySongID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if len(revisionModels) == 0 {
+		exists, err := s.songsRepo.Exists(ctx, id, true)
+		if err != nil {
+			return nil, fmt.Errorf("database error: %w", err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("%w: song with id %d does not exist", domain.ErrNotFound, id)
+		}
+	}
+
+	revisions := domain.MapSlice(revisionModels, converters.SongRevisionModels2Domain)
+
+	var previous *domain.Song
+	for _, revision := range revisions {
+		switch revision.Action {
+		case domain.SongRevisionDelete:
+			revision.Changes = domain.SongChanges(previous, nil)
+			previous = nil
+		default:
+			revision.Changes = domain.SongChanges(previous, &revision.Song)
+			previous = &revision.Song
+		}
+	}
+
+	s.logger.Infof("Retrieved %d revisions for song with ID %d", len(revisions), id)
+
+	return revisions, nil
+}
+
+// RestoreSongRevision brings the song back to its state in the revision,
+// taking it out of the trash or recreating it under the same ID when it was
+// purged. It returns nil when the song has no such revision.
+func (s *SongsService) RestoreSongRevision(ctx context.Context, id int64, revision int64) (*domain.Song, error) {
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	revisionModel, err := s.revisionsRepo.WithTX(tx).Get(ctx, id, revision)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if revisionModel == nil {
+		return nil, nil
+	}
+
+	song := converters.SongSnapshotModels2Domain(id, revisionModel.Snapshot)
+
+	// The artist may have been renamed or deleted since the revision.
+	if err := s.resolveArtist(ctx, tx, song); err != nil {
+		return nil, err
+	}
+
+	song.Stanzas = parseLyrics(song.SongText)
+	song.UpdatedAt = time.Now().Unix()
+
+	songsRepo := s.songsRepo.WithTX(tx)
+
+	if _, err := songsRepo.Restore(ctx, id); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	exists, err := songsRepo.Exists(ctx, id, false)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	var songModel *models.Song
+	if exists {
+		songModel, err = songsRepo.Update(ctx, converters.SongDomain2Models(song))
+	} else {
+		song.CreatedAt = song.UpdatedAt
+		song.EnrichmentStatus = domain.SongEnrichmentEnriched
+		if song.NeedsEnrichment() {
+			song.EnrichmentStatus = domain.SongEnrichmentPending
+		}
+		songModel, err = songsRepo.Create(ctx, converters.SongDomain2Models(song))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	restored := converters.SongModels2Domain(songModel)
+
+	if err := s.saveRevision(ctx, tx, domain.SongRevisionRestore, restored); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	s.logger.Infof("Song with ID %d restored to revision %d", id, revision)
+
+	return restored, nil
+}
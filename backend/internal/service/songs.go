@@ -3,9 +3,13 @@ package service
 import (
 	"context"
 	"fmt"
-	"strings"
+	"slices"
+	"time"
+	"unicode"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/musicinfo"
 	"github.com/salmon822/test_task/internal/pkg/logger"
 	"github.com/salmon822/test_task/internal/repository"
 	"github.com/salmon822/test_task/internal/repository/models"
@@ -15,21 +19,35 @@ import (
 type SongsService struct {
 	transactionRepo repository.Transactions
 	songsRepo       repository.Songs
+	artistsRepo     repository.Artists
+	songLyricsRepo  repository.SongLyrics
+	revisionsRepo   repository.SongRevisions
+	musicInfo       musicinfo.Client
 	logger          logger.Logger
 }
 
 func NewSongsService(
 	transactionRepo repository.Transactions,
 	songsRepo repository.Songs,
+	artistsRepo repository.Artists,
+	songLyricsRepo repository.SongLyrics,
+	revisionsRepo repository.SongRevisions,
+	musicInfo musicinfo.Client,
 	logger logger.Logger,
 ) Songs {
 	return &SongsService{
 		transactionRepo: transactionRepo,
 		songsRepo:       songsRepo,
+		artistsRepo:     artistsRepo,
+		songLyricsRepo:  songLyricsRepo,
+		revisionsRepo:   revisionsRepo,
+		musicInfo:       musicInfo,
 		logger:          logger,
 	}
 }
 
+// applyPartialUpdate sets the non-empty fields of songData on the song. It
+// keeps the semantics of the legacy update route, which cannot clear fields.
 func applyPartialUpdate(existingSong, songData *domain.Song) *domain.Song {
 	if songData.GroupName != "" {
 		existingSong.GroupName = songData.GroupName
@@ -40,48 +58,132 @@ func applyPartialUpdate(existingSong, songData *domain.Song) *domain.Song {
 	if songData.Link != "" {
 		existingSong.Link = songData.Link
 	}
-	if songData.ReleaseDate != 0 {
+	if !songData.ReleaseDate.IsZero() {
 		existingSong.ReleaseDate = songData.ReleaseDate
 	}
 	if songData.SongText != "" {
 		existingSong.SongText = songData.SongText
 	}
+	if songData.AlbumID != 0 {
+		existingSong.AlbumID = songData.AlbumID
+	}
+	if songData.DiscNumber != 0 {
+		existingSong.DiscNumber = songData.DiscNumber
+	}
+	if songData.TrackNumber != 0 {
+		existingSong.TrackNumber = songData.TrackNumber
+	}
+	if existingSong.AlbumID != 0 && existingSong.DiscNumber == 0 {
+		existingSong.DiscNumber = 1
+	}
 
 	return existingSong
 }
 
-func (s *SongsService) checkIfSongExists(ctx context.Context, id int64) (*domain.Song, error) {
-	song, err := s.songsRepo.GetById(ctx, id)
+// resolveArtist links the song to the artist matching its group name, creating
+// the artist on first use, and replaces the group name with the stored one.
+func (s *SongsService) resolveArtist(ctx context.Context, tx *sqlx.Tx, song *domain.Song) error {
+	artist, err := getOrCreateArtist(ctx, s.artistsRepo.WithTX(tx), song.GroupName)
+	if err != nil {
+		return err
+	}
+
+	song.ArtistID = artist.ID
+	song.GroupName = artist.Name
+
+	return nil
+}
+
+// lockSong reads the song and locks it until the end of the transaction, so
+// that it cannot be changed between the read and the write.
+func (s *SongsService) lockSong(ctx context.Context, tx *sqlx.Tx, id int64) (*domain.Song, error) {
+	song, err := s.songsRepo.WithTX(tx).GetByIdForUpdate(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("database error: %w", err)
 	}
 	if song == nil {
-		err := fmt.Errorf("song with id %d does not exist", id)
-		s.logger.Warnf("Validation failed: %v", err)
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, fmt.Errorf("%w: song with id %d does not exist", domain.ErrNotFound, id)
 	}
 
 	return converters.SongModels2Domain(song), nil
 }
 
 func (s *SongsService) CreateSong(ctx context.Context, song *domain.Song) (*domain.Song, error) {
+	prepareSong(song)
+	if song.EnrichmentStatus == domain.SongEnrichmentPending {
+		s.enrichNewSong(ctx, song)
+	}
+
 	tx, err := s.transactionRepo.StartTransaction(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("database error: %s", err)
+		return nil, fmt.Errorf("database error: %w", err)
 	}
 	defer tx.Rollback()
 
+	songDomain, err := s.createSong(ctx, tx, song)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return songDomain, nil
+}
+
+// prepareSong sets what a new song gets before it is saved. Songs with missing
+// details are pending, the enrichment worker fills them in unless they are
+// enriched before they are saved.
+func prepareSong(song *domain.Song) {
+	// The ID is assigned by the database.
+	song.ID = 0
+	if song.AlbumID != 0 && song.DiscNumber == 0 {
+		song.DiscNumber = 1
+	}
+
+	song.EnrichmentStatus = domain.SongEnrichmentEnriched
+	if song.NeedsEnrichment() {
+		song.EnrichmentStatus = domain.SongEnrichmentPending
+	}
+}
+
+// enrichNewSong fills in the missing details of a pending song before it is
+// saved, it is left pending when the music info service fails. It runs
+// outside of any transaction as it calls the service.
+func (s *SongsService) enrichNewSong(ctx context.Context, song *domain.Song) {
+	if err := enrichSong(ctx, s.musicInfo, song); err != nil {
+		s.logger.Warnf("Failed to enrich song %q - %q, saving it as pending: %v", song.GroupName, song.SongTitle, err)
+		song.EnrichmentAttempts = 1
+		song.EnrichmentLastError = err.Error()
+		return
+	}
+	if song.EnrichmentLastError != "" {
+		s.logger.Warnf("Song %q - %q enriched without a release date: %s", song.GroupName, song.SongTitle, song.EnrichmentLastError)
+	}
+}
+
+// createSong saves the song prepared by prepareSong in the transaction.
+func (s *SongsService) createSong(ctx context.Context, tx *sqlx.Tx, song *domain.Song) (*domain.Song, error) {
+	if err := s.resolveArtist(ctx, tx, song); err != nil {
+		return nil, err
+	}
+
+	song.Stanzas = parseLyrics(song.SongText)
+	song.CreatedAt = time.Now().Unix()
+	song.UpdatedAt = song.CreatedAt
+
 	songModel, err := s.songsRepo.WithTX(tx).Create(ctx, converters.SongDomain2Models(song))
 	if err != nil {
-		return nil, fmt.Errorf("database error: %s", err)
+		return nil, fmt.Errorf("database error: %w", err)
 	}
 
 	s.logger.Infof("Song created successfully with ID: %d", songModel.ID)
 
 	songDomain := converters.SongModels2Domain(songModel)
 
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("database error: %s", err)
+	if err := s.saveRevision(ctx, tx, domain.SongRevisionCreate, songDomain); err != nil {
+		return nil, err
 	}
 
 	return songDomain, nil
@@ -90,49 +192,121 @@ func (s *SongsService) CreateSong(ctx context.Context, song *domain.Song) (*doma
 func (s *SongsService) DeleteSong(ctx context.Context, id int64) error {
 	tx, err := s.transactionRepo.StartTransaction(ctx)
 	if err != nil {
-		return fmt.Errorf("database error: %s", err)
+		return fmt.Errorf("database error: %w", err)
 	}
 	defer tx.Rollback()
 
-	err = s.songsRepo.WithTX(tx).Delete(ctx, id)
+	if err := s.deleteSong(ctx, tx, id, nil); err != nil {
+		return err
+	}
+
+	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("database error: %s", err)
+		return fmt.Errorf("database error: %w", err)
 	}
 
-	s.logger.Infof("Song with ID %d deleted successfully", id)
+	return nil
+}
+
+// deleteSong moves the song to the trash in the transaction. It fails with
+// ErrNotFound when the song does not exist or is already in the trash.
+func (s *SongsService) deleteSong(ctx context.Context, tx *sqlx.Tx, id int64, match *domain.SongVersionMatch) error {
+	song, err := s.lockSong(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if !match.Matches(song.Version) {
+		return fmt.Errorf("%w: song %d is at version %d", domain.ErrSongVersionMismatch, id, song.Version)
+	}
 
-	err = tx.Commit()
+	// The revision of a deletion keeps the last state of the song so that it
+	// can be restored.
+	if err := s.saveRevision(ctx, tx, domain.SongRevisionDelete, song); err != nil {
+		return err
+	}
+
+	err = s.songsRepo.WithTX(tx).Delete(ctx, id, time.Now().Unix())
 	if err != nil {
-		return fmt.Errorf("database error: %s", err)
+		return fmt.Errorf("database error: %w", err)
 	}
 
+	s.logger.Infof("Song with ID %d moved to the trash", id)
+
 	return nil
 }
 
-func (s *SongsService) UpdateSong(ctx context.Context, id int64, songData *domain.Song) (*domain.Song, error) {
+// GetSong reads the song, without its lyrics unless withText is set.
+func (s *SongsService) GetSong(ctx context.Context, id int64, withText bool) (*domain.Song, error) {
+	getById := s.songsRepo.GetByIdWithoutText
+	if withText {
+		getById = s.songsRepo.GetById
+	}
+
+	song, err := getById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return converters.SongModels2Domain(song), nil
+}
+
+// RestoreSong takes the song out of the trash. It returns nil when the song is
+// not in the trash.
+func (s *SongsService) RestoreSong(ctx context.Context, id int64) (*domain.Song, error) {
 	tx, err := s.transactionRepo.StartTransaction(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("database error: %s", err)
+		return nil, fmt.Errorf("database error: %w", err)
 	}
 	defer tx.Rollback()
 
-	beforeUpdate, err := s.checkIfSongExists(ctx, id)
+	songsRepo := s.songsRepo.WithTX(tx)
+
+	restored, err := songsRepo.Restore(ctx, id)
 	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if !restored {
+		return nil, nil
+	}
+
+	songModel, err := songsRepo.GetById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	song := converters.SongModels2Domain(songModel)
+
+	if err := s.saveRevision(ctx, tx, domain.SongRevisionRestore, song); err != nil {
 		return nil, err
 	}
 
-	updatedSong := applyPartialUpdate(beforeUpdate, songData)
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
 
-	updatedData, err := s.songsRepo.WithTX(tx).Update(ctx, converters.SongDomain2Models(updatedSong))
+	s.logger.Infof("Song with ID %d restored from the trash", id)
+
+	return song, nil
+}
+
+// UpdateSong applies the set fields of songData to the song. The write is
+// refused with ErrSongVersionMismatch when the song is not at one of the
+// versions matched by match.
+func (s *SongsService) UpdateSong(ctx context.Context, id int64, songData *domain.Song, match *domain.SongVersionMatch) (*domain.Song, error) {
+	tx, err := s.transactionRepo.StartTransaction(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("database error: %s", err)
+		return nil, fmt.Errorf("database error: %w", err)
 	}
+	defer tx.Rollback()
 
-	song := converters.SongModels2Domain(updatedData)
+	song, err := s.updateSong(ctx, tx, id, songData, match)
+	if err != nil {
+		return nil, err
+	}
 
 	err = tx.Commit()
 	if err != nil {
-		return nil, fmt.Errorf("database error: %s", err)
+		return nil, fmt.Errorf("database error: %w", err)
 	}
 
 	s.logger.Infof("Song with ID %d updated successfully", id)
@@ -140,78 +314,311 @@ func (s *SongsService) UpdateSong(ctx context.Context, id int64, songData *domai
 	return song, nil
 }
 
-func (s *SongsService) GetSongTextByID(ctx context.Context, id int64, page int64, pageSize int64) (*domain.SongWithVerses, error) {
+func (s *SongsService) updateSong(ctx context.Context, tx *sqlx.Tx, id int64, songData *domain.Song, match *domain.SongVersionMatch) (*domain.Song, error) {
+	beforeUpdate, err := s.lockSong(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !match.Matches(beforeUpdate.Version) {
+		return nil, fmt.Errorf("%w: song %d is at version %d", domain.ErrSongVersionMismatch, id, beforeUpdate.Version)
+	}
+
+	return s.saveUpdatedSong(ctx, tx, applyPartialUpdate(beforeUpdate, songData))
+}
+
+// PatchSong applies the patch computed by patcher from the current state of
+// the song. Unlike UpdateSong, fields can be cleared. The write is refused with
+// ErrSongVersionMismatch when the song is not at one of the versions matched by
+// match, errors of patcher are returned as they are.
+func (s *SongsService) PatchSong(ctx context.Context, id int64, patcher domain.SongPatcher, match *domain.SongVersionMatch) (*domain.Song, error) {
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	beforeUpdate, err := s.lockSong(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !match.Matches(beforeUpdate.Version) {
+		return nil, fmt.Errorf("%w: song %d is at version %d", domain.ErrSongVersionMismatch, id, beforeUpdate.Version)
+	}
+
+	current := *beforeUpdate
+	patch, err := patcher(&current)
+	if err != nil {
+		return nil, err
+	}
+
+	song, err := s.saveUpdatedSong(ctx, tx, patch.Apply(beforeUpdate))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	s.logger.Infof("Song with ID %d patched successfully, fields: %v", id, patch.Fields)
+
+	return song, nil
+}
+
+// saveUpdatedSong writes the changed song locked by lockSong and its revision.
+func (s *SongsService) saveUpdatedSong(ctx context.Context, tx *sqlx.Tx, updatedSong *domain.Song) (*domain.Song, error) {
+	if err := s.resolveArtist(ctx, tx, updatedSong); err != nil {
+		return nil, err
+	}
+
+	updatedSong.Stanzas = parseLyrics(updatedSong.SongText)
+	updatedSong.UpdatedAt = time.Now().Unix()
+
+	updatedData, err := s.songsRepo.WithTX(tx).Update(ctx, converters.SongDomain2Models(updatedSong))
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	song := converters.SongModels2Domain(updatedData)
+
+	if err := s.saveRevision(ctx, tx, domain.SongRevisionUpdate, song); err != nil {
+		return nil, err
+	}
+
+	return song, nil
+}
+
+// GetSongTextByID returns a page of the song lyrics in the first of the
+// preferred languages the song is translated to, or of the original lyrics.
+func (s *SongsService) GetSongTextByID(ctx context.Context, id int64, pagination *domain.LyricsPagination) (*domain.SongWithVerses, error) {
 	tx, err := s.transactionRepo.StartTransaction(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("database error: %s", err)
+		return nil, fmt.Errorf("database error: %w", err)
 	}
 	defer tx.Rollback()
 
-	song, err := s.songsRepo.WithTX(tx).GetById(ctx, id)
+	songsRepo := s.songsRepo.WithTX(tx)
+	songLyricsRepo := s.songLyricsRepo.WithTX(tx)
+
+	songModel, err := songsRepo.GetById(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("database error: %s", err)
+		return nil, fmt.Errorf("database error: %w", err)
 	}
 
-	versesRaw := strings.Split(song.SongText, "\n\n")
-	var verses []string
+	song := converters.SongModels2Domain(songModel)
+	text := song.SongText
 
-	for _, verse := range versesRaw {
-		lines := strings.Split(verse, "\n")
-		for _, line := range lines {
-			if line != "" {
-				verses = append(verses, line)
-			}
+	var language string
+	if len(pagination.Languages) > 0 {
+		available, err := songLyricsRepo.GetLanguages(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("database error: %w", err)
 		}
+		language = matchLanguage(pagination.Languages, available)
 	}
 
-	start := (page - 1) * pageSize
-	end := start + pageSize
+	var stanzaModels []models.Stanza
+	if language != "" {
+		songLyrics, err := songLyricsRepo.Get(ctx, id, language)
+		if err != nil {
+			return nil, fmt.Errorf("database error: %w", err)
+		}
+		if songLyrics != nil {
+			text, stanzaModels = songLyrics.SongText, songLyrics.Stanzas
+		} else {
+			language = ""
+		}
+	}
+	if language == "" {
+		stanzaModels, err = songsRepo.GetStanzas(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("database error: %w", err)
+		}
+	}
+
+	stanzas := converters.StanzasModels2Domain(stanzaModels)
+	// Songs saved before their text started being parsed only have the raw
+	// text.
+	if stanzas == nil {
+		stanzas = parseLyrics(text)
+	}
 
-	if start >= int64(len(verses)) {
-		s.logger.Infof("No verses found for song ID %d on page %d", id, page)
-		return converters.SongModels2DomainSongDetails(&models.SongWithVerses{
-			Song:        *song,
-			TotalVerses: int64(len(verses)),
-			Page:        page,
-			PageSize:    pageSize,
-			Verses:      []string{},
-		}), nil
+	verses := lyricsLines(stanzas)
+	res := &domain.SongWithVerses{
+		Song:         *song,
+		Language:     language,
+		Unit:         pagination.Unit,
+		TotalVerses:  int64(len(verses)),
+		TotalStanzas: int64(len(stanzas)),
+		Page:         pagination.Page,
+		PageSize:     pagination.PageSize,
+		Verses:       []string{},
+		Stanzas:      []*domain.Stanza{},
 	}
 
-	if end > int64(len(verses)) {
-		end = int64(len(verses))
+	switch pagination.Unit {
+	case domain.LyricsUnitStanza:
+		res.Stanzas = paginate(stanzas, pagination.Page, pagination.PageSize)
+	default:
+		res.Verses = paginate(verses, pagination.Page, pagination.PageSize)
 	}
 
-	s.logger.Infof("Verses retrieved successfully for song ID %d", id)
+	s.logger.Infof("Lyrics retrieved successfully for song ID %d", id)
 
-	return converters.SongModels2DomainSongDetails(&models.SongWithVerses{
-		Song:        *song,
-		TotalVerses: int64(len(verses)),
-		Page:        page,
-		PageSize:    pageSize,
-		Verses:      verses[start:end],
-	}), nil
+	return res, nil
 }
 
-func (s *SongsService) GetFilteredSongs(ctx context.Context, filters *domain.SongFilters, page int64, pageSize int64) ([]*domain.Song, error) {
+// paginate returns the items of the page, or an empty slice when the page is
+// past the end. Pages and page sizes below 1 are read as 1.
+func paginate[T any](items []T, page int64, pageSize int64) []T {
+	page, pageSize = max(page, 1), max(pageSize, 1)
+	total := int64(len(items))
+
+	if page-1 >= (total/pageSize)+min(total%pageSize, 1) {
+		return []T{}
+	}
+
+	start := (page - 1) * pageSize
+	end := total
+	if pageSize < total-start {
+		end = start + pageSize
+	}
+
+	return items[start:end]
+}
+
+func (s *SongsService) GetFilteredSongs(ctx context.Context, filters *domain.SongFilters, pagination *domain.SongPagination) (*domain.SongList, error) {
 	tx, err := s.transactionRepo.StartTransaction(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("database error: %s", err)
+		return nil, fmt.Errorf("database error: %w", err)
 	}
 	defer tx.Rollback()
 
-	songs, err := s.songsRepo.WithTX(tx).GetFilteredSongs(ctx, converters.SongFiltersDomain2Models(filters), page, pageSize)
+	// Fuzzy listings are ordered by relevance unless asked otherwise.
+	if len(pagination.Sort) == 0 && filters.Fuzzy {
+		pagination.Sort = []domain.SongSort{{Field: domain.SongSortScore, Desc: true}}
+	}
+
+	// One extra song is requested to find out whether there is a next page.
+	repoPagination := &models.SongPagination{
+		Sort:   domain.MapSlice(pagination.Sort, converters.SongSortDomain2Models),
+		Limit:  pagination.PageSize + 1,
+		After:  converters.SongCursorDomain2Models(pagination.After),
+		Before: converters.SongCursorDomain2Models(pagination.Before),
+	}
+	if pagination.After == nil && pagination.Before == nil {
+		repoPagination.Offset = (pagination.Page - 1) * pagination.PageSize
+	}
+
+	repoFilters := converters.SongFiltersDomain2Models(filters)
+
+	songs, err := s.songsRepo.WithTX(tx).GetFilteredSongs(ctx, repoFilters, repoPagination)
 	if err != nil {
-		return nil, fmt.Errorf("database error: %s", err)
+		return nil, fmt.Errorf("database error: %w", err)
 	}
 
-	result := domain.MapSlice(songs, converters.SongModels2Domain)
+	total, err := s.songsRepo.WithTX(tx).CountFilteredSongs(ctx, repoFilters)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
 
 	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("database error: %s", err)
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	hasMore := int64(len(songs)) > pagination.PageSize
+	if hasMore {
+		songs = songs[:pagination.PageSize]
+	}
+	if pagination.Before != nil {
+		slices.Reverse(songs)
+	}
+
+	result := &domain.SongList{
+		Items:      domain.MapSlice(songs, converters.SongModels2Domain),
+		PageSize:   pagination.PageSize,
+		TotalItems: total,
+		TotalPages: (total + pagination.PageSize - 1) / pagination.PageSize,
+	}
+	if pagination.After == nil && pagination.Before == nil {
+		result.Page = pagination.Page
+	}
+	if len(result.Items) > 0 {
+		first, last := result.Items[0], result.Items[len(result.Items)-1]
+
+		switch {
+		case pagination.Before != nil:
+			result.NextCursor = songCursor(pagination.Sort, last)
+			if hasMore {
+				result.PrevCursor = songCursor(pagination.Sort, first)
+			}
+		case pagination.After != nil:
+			result.PrevCursor = songCursor(pagination.Sort, first)
+			if hasMore {
+				result.NextCursor = songCursor(pagination.Sort, last)
+			}
+		default:
+			if pagination.Page > 1 {
+				result.PrevCursor = songCursor(pagination.Sort, first)
+			}
+			if hasMore {
+				result.NextCursor = songCursor(pagination.Sort, last)
+			}
+		}
 	}
 
 	s.logger.Infof("Filtered songs retrieved successfully")
 
 	return result, nil
 }
+
+// songCursor returns the cursor of the song in a listing with the given sort,
+// its keys must follow the order used by the songs repository.
+func songCursor(sort []domain.SongSort, song *domain.Song) *domain.SongCursor {
+	cursor := &domain.SongCursor{ID: song.ID}
+	for _, field := range sort {
+		switch field.Field {
+		case domain.SongSortGroupName:
+			cursor.Keys = append(cursor.Keys, song.GroupName)
+		case domain.SongSortSongTitle:
+			cursor.Keys = append(cursor.Keys, song.SongTitle)
+		case domain.SongSortReleaseDate:
+			cursor.Keys = append(cursor.Keys, song.ReleaseDate.Format(time.DateOnly))
+		case domain.SongSortCreatedAt:
+			cursor.Keys = append(cursor.Keys, song.CreatedAt)
+		case domain.SongSortUpdatedAt:
+			cursor.Keys = append(cursor.Keys, song.UpdatedAt)
+		case domain.SongSortScore:
+			cursor.Keys = append(cursor.Keys, song.Score)
+		}
+	}
+
+	return cursor
+}
+
+func (s *SongsService) SearchSongs(ctx context.Context, search *domain.SongSearch, page int64, pageSize int64) ([]*domain.SongSearchResult, error) {
+	if search.Language == "" {
+		search.Language = detectSearchLanguage(search.Query)
+	}
+
+	results, err := s.songsRepo.SearchSongs(ctx, converters.SongSearchDomain2Models(search), page, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	s.logger.Infof("Found %d songs for search query %q", len(results), search.Query)
+
+	return domain.MapSlice(results, converters.SongSearchResultModels2Domain), nil
+}
+
+// detectSearchLanguage picks the Russian configuration for queries written in
+// Cyrillic and the English one otherwise.
+func detectSearchLanguage(query string) string {
+	for _, r := range query {
+		if unicode.Is(unicode.Cyrillic, r) {
+			return domain.SearchLanguageRussian
+		}
+	}
+
+	return domain.SearchLanguageEnglish
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/pkg/logger"
+	"github.com/salmon822/test_task/internal/repository"
+	"github.com/salmon822/test_task/internal/service/converters"
+)
+
+type SyncedLyricsService struct {
+	transactionRepo  repository.Transactions
+	syncedLyricsRepo repository.SyncedLyrics
+	songsRepo        repository.Songs
+	logger           logger.Logger
+}
+
+func NewSyncedLyricsService(
+	transactionRepo repository.Transactions,
+	syncedLyricsRepo repository.SyncedLyrics,
+	songsRepo repository.Songs,
+	logger logger.Logger,
+) SyncedLyrics {
+	return &SyncedLyricsService{
+		transactionRepo:  transactionRepo,
+		syncedLyricsRepo: syncedLyricsRepo,
+		songsRepo:        songsRepo,
+		logger:           logger,
+	}
+}
+
+func (s *SyncedLyricsService) ReplaceSyncedLyrics(ctx context.Context, songID int64, lines []*domain.SyncedLine) (*domain.SyncedLyrics, error) {
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	song, err := s.songsRepo.WithTX(tx).GetById(ctx, songID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	syncedLyricsRepo := s.syncedLyricsRepo.WithTX(tx)
+
+	if err := syncedLyricsRepo.Replace(ctx, songID, domain.MapSlice(lines, converters.SyncedLineDomain2Models)); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	stored, err := syncedLyricsRepo.GetBySongID(ctx, songID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	s.logger.Infof("Synced lyrics with %d lines saved for song with ID %d", len(stored), songID)
+
+	return &domain.SyncedLyrics{
+		Song:  *converters.SongModels2Domain(song),
+		Lines: domain.MapSlice(stored, converters.SyncedLineModels2Domain),
+	}, nil
+}
+
+func (s *SyncedLyricsService) GetSyncedLyrics(ctx context.Context, songID int64) (*domain.SyncedLyrics, error) {
+	song, err := s.songsRepo.GetById(ctx, songID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	lines, err := s.syncedLyricsRepo.GetBySongID(ctx, songID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return &domain.SyncedLyrics{
+		Song:  *converters.SongModels2Domain(song),
+		Lines: domain.MapSlice(lines, converters.SyncedLineModels2Domain),
+	}, nil
+}
+
+func (s *SyncedLyricsService) GetSyncedLyricsAt(ctx context.Context, songID int64, position time.Duration) (*domain.SyncedLyricsPosition, error) {
+	if _, err := s.songsRepo.GetByIdWithoutText(ctx, songID); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	line, next, err := s.syncedLyricsRepo.GetLinesAround(ctx, songID, position.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return &domain.SyncedLyricsPosition{
+		Position: position,
+		Line:     converters.SyncedLineModels2Domain(line),
+		Next:     converters.SyncedLineModels2Domain(next),
+	}, nil
+}
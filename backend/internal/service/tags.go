@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/salmon822/test_task/internal/domain"
+	"github.com/salmon822/test_task/internal/pkg/logger"
+	"github.com/salmon822/test_task/internal/repository"
+	"github.com/salmon822/test_task/internal/service/converters"
+)
+
+type TagsService struct {
+	transactionRepo repository.Transactions
+	tagsRepo        repository.Tags
+	songsRepo       repository.Songs
+	logger          logger.Logger
+}
+
+func NewTagsService(
+	transactionRepo repository.Transactions,
+	tagsRepo repository.Tags,
+	songsRepo repository.Songs,
+	logger logger.Logger,
+) Tags {
+	return &TagsService{
+		transactionRepo: transactionRepo,
+		tagsRepo:        tagsRepo,
+		songsRepo:       songsRepo,
+		logger:          logger,
+	}
+}
+
+func (s *TagsService) GetSongTags(ctx context.Context, songID int64) ([]*domain.Tag, error) {
+	if _, err := s.songsRepo.GetByIdWithoutText(ctx, songID); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	tags, err := s.tagsRepo.GetSongTags(ctx, songID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return domain.MapSlice(tags, converters.TagModels2Domain), nil
+}
+
+func (s *TagsService) AddSongTags(ctx context.Context, songID int64, tags []*domain.Tag) ([]*domain.Tag, error) {
+	return s.assignSongTags(ctx, songID, tags, false)
+}
+
+func (s *TagsService) ReplaceSongTags(ctx context.Context, songID int64, tags []*domain.Tag) ([]*domain.Tag, error) {
+	return s.assignSongTags(ctx, songID, tags, true)
+}
+
+func (s *TagsService) RemoveSongTag(ctx context.Context, songID int64, tagID int64) error {
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := s.tagsRepo.WithTX(tx).RemoveSongTag(ctx, songID, tagID); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	s.logger.Infof("Tag %d removed from song with ID %d", tagID, songID)
+
+	return nil
+}
+
+func (s *TagsService) assignSongTags(ctx context.Context, songID int64, tags []*domain.Tag, replace bool) ([]*domain.Tag, error) {
+	tx, err := s.transactionRepo.StartTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := s.songsRepo.WithTX(tx).GetById(ctx, songID); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	tagsRepo := s.tagsRepo.WithTX(tx)
+
+	if replace {
+		if err := tagsRepo.RemoveSongTags(ctx, songID); err != nil {
+			return nil, fmt.Errorf("database error: %w", err)
+		}
+	}
+
+	for _, tag := range tags {
+		tag.Name = normalizeName(tag.Name)
+
+		tagModel, err := tagsRepo.GetOrCreate(ctx, converters.TagDomain2Models(tag))
+		if err != nil {
+			return nil, fmt.Errorf("database error: %w", err)
+		}
+
+		if err := tagsRepo.AddSongTag(ctx, songID, tagModel.ID); err != nil {
+			return nil, fmt.Errorf("database error: %w", err)
+		}
+	}
+
+	songTags, err := tagsRepo.GetSongTags(ctx, songID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	s.logger.Infof("Tags assigned successfully to song with ID %d", songID)
+
+	return domain.MapSlice(songTags, converters.TagModels2Domain), nil
+}
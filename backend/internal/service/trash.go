@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/salmon822/test_task/internal/config"
+	"github.com/salmon822/test_task/internal/pkg/logger"
+	"github.com/salmon822/test_task/internal/repository"
+)
+
+type TrashService struct {
+	songsRepo repository.Songs
+	cfg       *config.TrashPurgeWorkerConfig
+	logger    logger.Logger
+}
+
+func NewTrashService(
+	songsRepo repository.Songs,
+	cfg *config.TrashPurgeWorkerConfig,
+	logger logger.Logger,
+) Trash {
+	return &TrashService{
+		songsRepo: songsRepo,
+		cfg:       cfg,
+		logger:    logger,
+	}
+}
+
+// PurgeDeletedSongs permanently removes a batch of the songs that have been in
+// the trash for longer than the retention period.
+func (s *TrashService) PurgeDeletedSongs(ctx context.Context) (int64, error) {
+	deletedBefore := time.Now().Add(-s.cfg.Retention).Unix()
+
+	purged, err := s.songsRepo.Purge(ctx, deletedBefore, s.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("database error: %w", err)
+	}
+
+	if purged > 0 {
+		s.logger.Infof("Purged %d songs deleted before %d", purged, deletedBefore)
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,32 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/salmon822/test_task/internal/pkg/logger"
+	"github.com/salmon822/test_task/internal/service"
+)
+
+type EnrichmentWorker struct {
+	enrichment service.Enrichment
+	interval   time.Duration
+	logger     logger.Logger
+}
+
+func NewEnrichmentWorker(
+	enrichment service.Enrichment,
+	interval time.Duration,
+	logger logger.Logger,
+) *EnrichmentWorker {
+	return &EnrichmentWorker{
+		enrichment: enrichment,
+		interval:   interval,
+		logger:     logger,
+	}
+}
+
+// Run re-enriches pending songs every interval until ctx is cancelled.
+func (w *EnrichmentWorker) Run(ctx context.Context) {
+	runPeriodically(ctx, w.logger, "Enrichment worker", "enriched", w.interval, w.enrichment.EnrichPendingSongs)
+}
@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/salmon822/test_task/internal/pkg/logger"
+)
+
+// runPeriodically calls task every interval until ctx is cancelled, logging
+// failures and the number of songs the task reports it has processed, e.g.
+// "Enrichment worker enriched 3 songs" for name "Enrichment worker" and action
+// "enriched".
+func runPeriodically[N int | int64](
+	ctx context.Context,
+	logger logger.Logger,
+	name string,
+	action string,
+	interval time.Duration,
+	task func(ctx context.Context) (N, error),
+) {
+	logger.Infof("%s started, interval: %s", name, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Infof("%s stopped", name)
+			return
+		case <-ticker.C:
+			processed, err := task(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.Errorf("%s failed: %v", name, err)
+				}
+				continue
+			}
+
+			if processed > 0 {
+				logger.Infof("%s %s %d songs", name, action, processed)
+			}
+		}
+	}
+}
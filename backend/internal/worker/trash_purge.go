@@ -0,0 +1,33 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/salmon822/test_task/internal/pkg/logger"
+	"github.com/salmon822/test_task/internal/service"
+)
+
+type TrashPurgeWorker struct {
+	trash    service.Trash
+	interval time.Duration
+	logger   logger.Logger
+}
+
+func NewTrashPurgeWorker(
+	trash service.Trash,
+	interval time.Duration,
+	logger logger.Logger,
+) *TrashPurgeWorker {
+	return &TrashPurgeWorker{
+		trash:    trash,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run purges songs kept in the trash past the retention period every interval
+// until ctx is cancelled.
+func (w *TrashPurgeWorker) Run(ctx context.Context) {
+	runPeriodically(ctx, w.logger, "Trash purge worker", "purged", w.interval, w.trash.PurgeDeletedSongs)
+}
@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE songs ADD COLUMN enrichment_status VARCHAR(16) NOT NULL DEFAULT 'pending';
+
+UPDATE songs
+SET enrichment_status = 'enriched'
+WHERE release_date <> 0
+  AND COALESCE(song_text, '') <> ''
+  AND COALESCE(link, '') <> '';
+
+-- +goose Down
+ALTER TABLE songs DROP COLUMN IF EXISTS enrichment_status;
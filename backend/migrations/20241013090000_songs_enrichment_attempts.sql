@@ -0,0 +1,14 @@
+-- +goose Up
+ALTER TABLE songs ADD COLUMN enrichment_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE songs ADD COLUMN enrichment_last_error TEXT NOT NULL DEFAULT '';
+ALTER TABLE songs ADD COLUMN enrichment_next_attempt_at BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_songs_enrichment_pending ON songs(enrichment_next_attempt_at)
+    WHERE enrichment_status = 'pending';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_songs_enrichment_pending;
+
+ALTER TABLE songs DROP COLUMN IF EXISTS enrichment_next_attempt_at;
+ALTER TABLE songs DROP COLUMN IF EXISTS enrichment_last_error;
+ALTER TABLE songs DROP COLUMN IF EXISTS enrichment_attempts;
@@ -0,0 +1,43 @@
+-- +goose Up
+CREATE TABLE artists (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at BIGINT NOT NULL DEFAULT 0,
+    updated_at BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX idx_artists_name_normalized ON artists (lower(regexp_replace(btrim(name), '\s+', ' ', 'g')));
+
+INSERT INTO artists (name)
+SELECT DISTINCT ON (lower(regexp_replace(btrim(group_name), '\s+', ' ', 'g'))) regexp_replace(btrim(group_name), '\s+', ' ', 'g')
+FROM songs
+ORDER BY lower(regexp_replace(btrim(group_name), '\s+', ' ', 'g')), regexp_replace(btrim(group_name), '\s+', ' ', 'g');
+
+ALTER TABLE songs ADD COLUMN artist_id INT;
+
+UPDATE songs
+SET artist_id = artists.id
+FROM artists
+WHERE lower(regexp_replace(btrim(artists.name), '\s+', ' ', 'g')) = lower(regexp_replace(btrim(songs.group_name), '\s+', ' ', 'g'));
+
+ALTER TABLE songs ALTER COLUMN artist_id SET NOT NULL;
+ALTER TABLE songs ADD CONSTRAINT fk_songs_artist_id FOREIGN KEY (artist_id) REFERENCES artists(id) ON DELETE RESTRICT;
+
+CREATE INDEX idx_songs_artist_id ON songs(artist_id);
+
+DROP INDEX IF EXISTS idx_songs_group_name;
+ALTER TABLE songs DROP COLUMN group_name;
+
+-- +goose Down
+ALTER TABLE songs ADD COLUMN group_name VARCHAR(255);
+
+UPDATE songs
+SET group_name = artists.name
+FROM artists
+WHERE artists.id = songs.artist_id;
+
+ALTER TABLE songs ALTER COLUMN group_name SET NOT NULL;
+CREATE INDEX idx_songs_group_name ON songs(group_name);
+
+ALTER TABLE songs DROP COLUMN artist_id;
+DROP TABLE IF EXISTS artists;
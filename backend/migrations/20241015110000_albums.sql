@@ -0,0 +1,28 @@
+-- +goose Up
+CREATE TABLE albums (
+    id SERIAL PRIMARY KEY,
+    artist_id INT NOT NULL REFERENCES artists(id) ON DELETE RESTRICT,
+    title VARCHAR(255) NOT NULL,
+    release_date BIGINT NOT NULL DEFAULT 0,
+    cover_link VARCHAR(255) NOT NULL DEFAULT '',
+    created_at BIGINT NOT NULL DEFAULT 0,
+    updated_at BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_albums_artist_id ON albums(artist_id);
+
+ALTER TABLE songs ADD COLUMN album_id INT REFERENCES albums(id) ON DELETE SET NULL;
+ALTER TABLE songs ADD COLUMN disc_number INT;
+ALTER TABLE songs ADD COLUMN track_number INT;
+
+-- Deferrable so that tracks can swap positions inside a single transaction.
+ALTER TABLE songs ADD CONSTRAINT uq_songs_album_track UNIQUE (album_id, disc_number, track_number)
+    DEFERRABLE INITIALLY IMMEDIATE;
+
+-- +goose Down
+ALTER TABLE songs DROP CONSTRAINT IF EXISTS uq_songs_album_track;
+ALTER TABLE songs DROP COLUMN IF EXISTS track_number;
+ALTER TABLE songs DROP COLUMN IF EXISTS disc_number;
+ALTER TABLE songs DROP COLUMN IF EXISTS album_id;
+
+DROP TABLE IF EXISTS albums;
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE tags (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(16) NOT NULL DEFAULT 'tag',
+    CONSTRAINT chk_tags_kind CHECK (kind IN ('genre', 'tag'))
+);
+
+CREATE UNIQUE INDEX idx_tags_kind_name_normalized ON tags (kind, lower(regexp_replace(btrim(name), '\s+', ' ', 'g')));
+
+CREATE TABLE song_tags (
+    song_id INT NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    tag_id INT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (song_id, tag_id)
+);
+
+CREATE INDEX idx_song_tags_tag_id ON song_tags(tag_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS song_tags;
+DROP TABLE IF EXISTS tags;
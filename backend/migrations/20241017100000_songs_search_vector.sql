@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE songs ADD COLUMN search_vector_en tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', COALESCE(song_title, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(song_text, '')), 'B')
+) STORED;
+
+ALTER TABLE songs ADD COLUMN search_vector_ru tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', COALESCE(song_title, '')), 'A') ||
+    setweight(to_tsvector('russian', COALESCE(song_text, '')), 'B')
+) STORED;
+
+CREATE INDEX idx_songs_search_vector_en ON songs USING GIN (search_vector_en);
+CREATE INDEX idx_songs_search_vector_ru ON songs USING GIN (search_vector_ru);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_songs_search_vector_ru;
+DROP INDEX IF EXISTS idx_songs_search_vector_en;
+
+ALTER TABLE songs DROP COLUMN IF EXISTS search_vector_ru;
+ALTER TABLE songs DROP COLUMN IF EXISTS search_vector_en;
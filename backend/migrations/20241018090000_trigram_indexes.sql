@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_artists_name_trgm ON artists USING GIN (name gin_trgm_ops);
+CREATE INDEX idx_songs_song_title_trgm ON songs USING GIN (song_title gin_trgm_ops);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_songs_song_title_trgm;
+DROP INDEX IF EXISTS idx_artists_name_trgm;
+
+DROP EXTENSION IF EXISTS pg_trgm;
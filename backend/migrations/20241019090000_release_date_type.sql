@@ -0,0 +1,31 @@
+-- +goose Up
+-- release_date used to hold YYYYMMDD numbers with 0 for unknown dates. Other
+-- positive values are read as Unix timestamps.
+ALTER TABLE songs ALTER COLUMN release_date DROP NOT NULL;
+ALTER TABLE songs ALTER COLUMN release_date TYPE DATE USING (
+    CASE
+        WHEN release_date BETWEEN 10000101 AND 99991231 THEN to_date(release_date::text, 'YYYYMMDD')
+        WHEN release_date > 0 THEN to_timestamp(release_date)::date
+    END
+);
+
+ALTER TABLE albums ALTER COLUMN release_date DROP DEFAULT;
+ALTER TABLE albums ALTER COLUMN release_date DROP NOT NULL;
+ALTER TABLE albums ALTER COLUMN release_date TYPE DATE USING (
+    CASE
+        WHEN release_date BETWEEN 10000101 AND 99991231 THEN to_date(release_date::text, 'YYYYMMDD')
+        WHEN release_date > 0 THEN to_timestamp(release_date)::date
+    END
+);
+
+CREATE INDEX idx_songs_release_date ON songs(release_date);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_songs_release_date;
+
+ALTER TABLE albums ALTER COLUMN release_date TYPE BIGINT USING COALESCE(to_char(release_date, 'YYYYMMDD')::bigint, 0);
+ALTER TABLE albums ALTER COLUMN release_date SET NOT NULL;
+ALTER TABLE albums ALTER COLUMN release_date SET DEFAULT 0;
+
+ALTER TABLE songs ALTER COLUMN release_date TYPE BIGINT USING COALESCE(to_char(release_date, 'YYYYMMDD')::bigint, 0);
+ALTER TABLE songs ALTER COLUMN release_date SET NOT NULL;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- lyrics holds the stanzas parsed from song_text. It stays NULL for songs
+-- written before it was added, their text is parsed when it is read.
+ALTER TABLE songs ADD COLUMN lyrics JSONB;
+
+-- +goose Down
+ALTER TABLE songs DROP COLUMN IF EXISTS lyrics;
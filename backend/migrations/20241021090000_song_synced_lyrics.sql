@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE song_synced_lyrics (
+    song_id INT NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    line_number INT NOT NULL,
+    start_ms BIGINT NOT NULL,
+    text TEXT NOT NULL,
+    PRIMARY KEY (song_id, line_number),
+    CONSTRAINT uq_song_synced_lyrics_start UNIQUE (song_id, start_ms),
+    CONSTRAINT chk_song_synced_lyrics_start CHECK (start_ms >= 0)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS song_synced_lyrics;
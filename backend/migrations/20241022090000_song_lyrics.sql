@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE song_lyrics (
+    song_id INT NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    language VARCHAR(35) NOT NULL,
+    song_text TEXT NOT NULL,
+    lyrics JSONB NOT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (song_id, language)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS song_lyrics;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Revisions outlive the songs they belong to, so song_id has no foreign key.
+CREATE TABLE song_revisions (
+    id SERIAL PRIMARY KEY,
+    song_id INT NOT NULL,
+    revision INT NOT NULL,
+    action VARCHAR(16) NOT NULL,
+    snapshot JSONB NOT NULL,
+    created_at BIGINT NOT NULL,
+    CONSTRAINT uq_song_revisions_revision UNIQUE (song_id, revision),
+    CONSTRAINT chk_song_revisions_action CHECK (action IN ('create', 'update', 'delete', 'restore'))
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS song_revisions;
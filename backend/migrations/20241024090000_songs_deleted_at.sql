@@ -0,0 +1,10 @@
+-- +goose Up
+ALTER TABLE songs ADD COLUMN deleted_at BIGINT;
+
+CREATE INDEX idx_songs_deleted_at ON songs(deleted_at)
+    WHERE deleted_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_songs_deleted_at;
+
+ALTER TABLE songs DROP COLUMN IF EXISTS deleted_at;
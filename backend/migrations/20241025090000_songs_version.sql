@@ -0,0 +1,7 @@
+-- +goose Up
+-- version counts the writes to the song, it is compared with If-Match to
+-- reject updates based on a stale read.
+ALTER TABLE songs ADD COLUMN version INT NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE songs DROP COLUMN IF EXISTS version;
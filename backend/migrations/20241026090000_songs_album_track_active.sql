@@ -0,0 +1,14 @@
+-- +goose Up
+-- Songs in the trash no longer hold their album track position. A partial
+-- unique index cannot be deferred, which reordering tracks relies on, so the
+-- constraint becomes an exclusion constraint over the songs not in the trash.
+ALTER TABLE songs DROP CONSTRAINT IF EXISTS uq_songs_album_track;
+ALTER TABLE songs ADD CONSTRAINT uq_songs_album_track
+    EXCLUDE USING btree (album_id WITH =, disc_number WITH =, track_number WITH =)
+    WHERE (deleted_at IS NULL)
+    DEFERRABLE INITIALLY IMMEDIATE;
+
+-- +goose Down
+ALTER TABLE songs DROP CONSTRAINT IF EXISTS uq_songs_album_track;
+ALTER TABLE songs ADD CONSTRAINT uq_songs_album_track UNIQUE (album_id, disc_number, track_number)
+    DEFERRABLE INITIALLY IMMEDIATE;
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DateFormat is the ISO 8601 calendar date format used by the API.
+const DateFormat = time.DateOnly
+
+// Date is a calendar date. It is written as an ISO 8601 string. It is read from
+// a string in one of the formats of ParseDate, or from a number of Unix seconds,
+// 0 standing for an unknown date like it did before release dates were DATEs.
+type Date struct {
+	time.Time
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Format(DateFormat))
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		var seconds int64
+		if err := json.Unmarshal(data, &seconds); err != nil {
+			return fmt.Errorf("invalid date %s, expected a string or a Unix timestamp", data)
+		}
+		d.Time = ParseUnixDate(seconds)
+		return nil
+	}
+
+	date, err := ParseDate(value)
+	if err != nil {
+		return err
+	}
+	d.Time = date
+
+	return nil
+}
+
+// ParseDate parses an ISO 8601 date ("2006-07-16"), an RFC 3339 timestamp or a
+// YYYYMMDD date ("20060716"), the format release dates were sent in before.
+// Timestamps are cut to their date.
+func ParseDate(value string) (time.Time, error) {
+	if date, err := time.Parse(DateFormat, value); err == nil {
+		return date, nil
+	}
+	if date, err := time.Parse("20060102", value); err == nil && len(value) == len("20060102") {
+		return date, nil
+	}
+	if timestamp, err := time.Parse(time.RFC3339, value); err == nil {
+		return truncateToDate(timestamp), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD, YYYYMMDD or an RFC 3339 timestamp", value)
+}
+
+// ParseUnixDate returns the UTC date of a Unix timestamp in seconds, and the
+// zero time, an unknown date, for 0.
+func ParseUnixDate(seconds int64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+	return truncateToDate(time.Unix(seconds, 0).UTC())
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
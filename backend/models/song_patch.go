@@ -0,0 +1,112 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+)
+
+// songReadOnlyFields are the members of a song that are set by the server.
+var songReadOnlyFields = []string{
+	"id", "artistId", "createdAt", "updatedAt", "deletedAt", "enrichmentStatus", "score",
+}
+
+// SongPatch is a song read from a JSON Merge Patch (RFC 7396). Fields lists
+// the members of the patch, null ones included, so that a field cleared with
+// null or an empty value is told apart from an absent one.
+type SongPatch struct {
+	Song   Song
+	Fields []string
+}
+
+func (p *SongPatch) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	if members == nil {
+		return fmt.Errorf("a song patch must be an object")
+	}
+
+	p.Fields = slices.Sorted(maps.Keys(members))
+	p.Song = Song{}
+
+	return json.Unmarshal(data, &p.Song)
+}
+
+func (p *SongPatch) Has(field string) bool {
+	return slices.Contains(p.Fields, field)
+}
+
+func (p *SongPatch) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	for _, field := range p.Fields {
+		var err error
+		switch field {
+		case "groupName":
+			err = validation.Validate(strings.TrimSpace(p.Song.GroupName), validation.Required)
+		case "songTitle":
+			err = validation.Validate(p.Song.SongTitle, validation.Required)
+		case "link":
+			if p.Song.Link != "" {
+				err = validation.Validate(p.Song.Link, is.URL)
+			}
+		case "albumId":
+			err = validateOptionalID(p.Song.AlbumId)
+		case "discNumber":
+			err = validateOptionalID(p.Song.DiscNumber)
+		case "trackNumber":
+			err = validateOptionalID(p.Song.TrackNumber)
+		case "releaseDate", "songText":
+		default:
+			if slices.Contains(songReadOnlyFields, field) {
+				err = fmt.Errorf("cannot be changed")
+			} else {
+				err = fmt.Errorf("is not a song field")
+			}
+		}
+		if err != nil {
+			res = append(res, fieldError("/"+field, err))
+		}
+	}
+
+	if p.Has("albumId") && p.Song.AlbumId == nil && (p.Song.DiscNumber != nil || p.Song.TrackNumber != nil) {
+		res = append(res, fieldError("/albumId", fmt.Errorf("is required when a track position is set")))
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func validateOptionalID(value *int64) error {
+	if value == nil {
+		return nil
+	}
+	return validation.Validate(*value, validation.Min(1))
+}
+
+// SongPatchRequest is a SongUpdateRequest read as a patch of the song, with
+// the JSON Merge Patch semantics of SongPatch.
+type SongPatchRequest struct {
+	Song *SongPatch `json:"song"`
+}
+
+func (r *SongPatchRequest) Validate(formats strfmt.Registry) error {
+	if r.Song == nil {
+		return errors.CompositeValidationError(fieldError("/song", errBlank))
+	}
+	if err := r.Song.Validate(formats); err != nil {
+		return prefixFieldErrors("/song", err)
+	}
+	return nil
+}
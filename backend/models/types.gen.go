@@ -3,23 +3,231 @@
 // Code generated by github.com/deepmap/oapi-codegen version v1.16.3 DO NOT EDIT.
 package models
 
-// ErrorResponse defines model for ErrorResponse.
-type ErrorResponse struct {
-	// Code Error code.
-	Code *int64 `json:"code,omitempty"`
+// Defines values for ErrorResponseType.
+const (
+	Conflict             ErrorResponseType = "/problems/conflict"
+	InternalError        ErrorResponseType = "/problems/internal-error"
+	InvalidRequest       ErrorResponseType = "/problems/invalid-request"
+	NotFound             ErrorResponseType = "/problems/not-found"
+	PreconditionFailed   ErrorResponseType = "/problems/precondition-failed"
+	RequestCanceled      ErrorResponseType = "/problems/request-canceled"
+	Timeout              ErrorResponseType = "/problems/timeout"
+	UnsupportedMediaType ErrorResponseType = "/problems/unsupported-media-type"
+	ValidationFailed     ErrorResponseType = "/problems/validation-failed"
+)
+
+// Defines values for SongBatchMode.
+const (
+	SongBatchModeAtomic      SongBatchMode = "atomic"
+	SongBatchModeIndependent SongBatchMode = "independent"
+)
+
+// Defines values for SongBatchOperationOp.
+const (
+	SongBatchOperationOpCreate SongBatchOperationOp = "create"
+	SongBatchOperationOpDelete SongBatchOperationOp = "delete"
+	SongBatchOperationOpUpdate SongBatchOperationOp = "update"
+)
+
+// Defines values for SongBatchResultStatus.
+const (
+	SongBatchResultStatusFailed    SongBatchResultStatus = "failed"
+	SongBatchResultStatusSkipped   SongBatchResultStatus = "skipped"
+	SongBatchResultStatusSucceeded SongBatchResultStatus = "succeeded"
+)
+
+// Defines values for SongEnrichmentStatus.
+const (
+	Enriched SongEnrichmentStatus = "enriched"
+	Failed   SongEnrichmentStatus = "failed"
+	Pending  SongEnrichmentStatus = "pending"
+)
+
+// Defines values for SongImportMode.
+const (
+	Atomic     SongImportMode = "atomic"
+	BestEffort SongImportMode = "bestEffort"
+)
+
+// Defines values for SongImportRowStatus.
+const (
+	SongImportRowStatusFailed   SongImportRowStatus = "failed"
+	SongImportRowStatusImported SongImportRowStatus = "imported"
+	SongImportRowStatusSkipped  SongImportRowStatus = "skipped"
+)
+
+// Defines values for SongRevisionAction.
+const (
+	SongRevisionActionCreate  SongRevisionAction = "create"
+	SongRevisionActionDelete  SongRevisionAction = "delete"
+	SongRevisionActionRestore SongRevisionAction = "restore"
+	SongRevisionActionUpdate  SongRevisionAction = "update"
+)
+
+// Defines values for SongWithVersesUnit.
+const (
+	SongWithVersesUnitLine   SongWithVersesUnit = "line"
+	SongWithVersesUnitStanza SongWithVersesUnit = "stanza"
+)
+
+// Defines values for TagKind.
+const (
+	TagKindGenre TagKind = "genre"
+	TagKindTag   TagKind = "tag"
+)
+
+// Defines values for GetSongsFilterParamsTagsMatch.
+const (
+	All GetSongsFilterParamsTagsMatch = "all"
+	Any GetSongsFilterParamsTagsMatch = "any"
+)
+
+// Defines values for GetSongsSearchParamsLang.
+const (
+	En GetSongsSearchParamsLang = "en"
+	Ru GetSongsSearchParamsLang = "ru"
+)
+
+// Defines values for GetSongsExportParamsFormat.
+const (
+	GetSongsExportParamsFormatCsv    GetSongsExportParamsFormat = "csv"
+	GetSongsExportParamsFormatJson   GetSongsExportParamsFormat = "json"
+	GetSongsExportParamsFormatNdjson GetSongsExportParamsFormat = "ndjson"
+)
+
+// Defines values for PostSongsImportParamsFormat.
+const (
+	Csv    PostSongsImportParamsFormat = "csv"
+	Ndjson PostSongsImportParamsFormat = "ndjson"
+)
+
+// Album defines model for Album.
+type Album struct {
+	// ArtistId Identifier of the album artist.
+	ArtistId *int64 `json:"artistId,omitempty"`
+
+	// CoverLink Link to the album cover.
+	CoverLink string `json:"coverLink"`
+
+	// CreatedAt Record creation timestamp.
+	CreatedAt int64 `json:"createdAt"`
+
+	// GroupName Name of the group or artist.
+	GroupName string `json:"groupName"`
+
+	// Id Album identifier.
+	Id int64 `json:"id"`
+
+	// ReleaseDate Release date in ISO 8601 format, absent when unknown. A YYYYMMDD string ("20060716") or an RFC 3339 timestamp is also accepted as input, as is a JSON number of Unix seconds, 0 standing for an unknown date. Numbers are never read as YYYYMMDD.
+	ReleaseDate *Date `json:"releaseDate,omitempty"`
+
+	// Title Title of the album.
+	Title string `json:"title"`
+
+	// UpdatedAt Record update timestamp.
+	UpdatedAt int64 `json:"updatedAt"`
+}
+
+// AlbumCreateRequest defines model for AlbumCreateRequest.
+type AlbumCreateRequest struct {
+	Album *Album `json:"album,omitempty"`
+}
 
-	// Detail Detailed information about the error.
+// AlbumTracksReorderRequest defines model for AlbumTracksReorderRequest.
+type AlbumTracksReorderRequest struct {
+	// Tracks New positions of the album tracks.
+	Tracks []TrackPosition `json:"tracks"`
+}
+
+// AlbumTracksResponse defines model for AlbumTracksResponse.
+type AlbumTracksResponse struct {
+	Album *Album `json:"album,omitempty"`
+
+	// Tracks Album tracks ordered by disc and track number.
+	Tracks []*Song `json:"tracks"`
+}
+
+// Artist defines model for Artist.
+type Artist struct {
+	// CreatedAt Record creation timestamp.
+	CreatedAt int64 `json:"createdAt"`
+
+	// Id Artist identifier.
+	Id int64 `json:"id"`
+
+	// Name Name of the group or artist.
+	Name string `json:"name"`
+
+	// UpdatedAt Record update timestamp.
+	UpdatedAt int64 `json:"updatedAt"`
+}
+
+// ArtistCreateRequest defines model for ArtistCreateRequest.
+type ArtistCreateRequest struct {
+	Artist *Artist `json:"artist,omitempty"`
+}
+
+// ArtistUpdateRequest defines model for ArtistUpdateRequest.
+type ArtistUpdateRequest struct {
+	Artist *Artist `json:"artist,omitempty"`
+}
+
+// ErrorResponse An RFC 7807 problem details object.
+type ErrorResponse struct {
+	// Detail Explanation specific to this occurrence of the problem. Internal errors get a generic explanation, the error itself is only logged.
 	Detail *string `json:"detail,omitempty"`
 
-	// Message Error message.
-	Message *string `json:"message,omitempty"`
+	// Errors Invalid fields of the request body.
+	Errors *[]ErrorResponseField `json:"errors,omitempty"`
+
+	// Instance Path of the request that caused the problem.
+	Instance *string `json:"instance,omitempty"`
+
+	// RequestId Identifier of the request, also sent in the X-Request-ID header.
+	RequestId *string `json:"requestId,omitempty"`
+
+	// Status HTTP status code.
+	Status int64 `json:"status"`
+
+	// Title Short summary of the problem type.
+	Title string `json:"title"`
+
+	// Type URI reference identifying the problem type.
+	Type ErrorResponseType `json:"type"`
+}
+
+// ErrorResponseType URI reference identifying the problem type.
+type ErrorResponseType string
+
+// ErrorResponseField defines model for ErrorResponseField.
+type ErrorResponseField struct {
+	// Detail What is wrong with the field.
+	Detail string `json:"detail"`
+
+	// Pointer JSON pointer to the field in the request body.
+	Pointer string `json:"pointer"`
 }
 
 // Song defines model for Song.
 type Song struct {
+	// AlbumId Identifier of the album the song belongs to.
+	AlbumId *int64 `json:"albumId,omitempty"`
+
+	// ArtistId Identifier of the song artist.
+	ArtistId *int64 `json:"artistId,omitempty"`
+
 	// CreatedAt Record creation timestamp.
 	CreatedAt int64 `json:"createdAt"`
 
+	// DeletedAt Time the song was moved to the trash, as a Unix timestamp, only set for songs in the trash.
+	DeletedAt *int64 `json:"deletedAt,omitempty"`
+
+	// DiscNumber Disc number within the album.
+	DiscNumber *int64 `json:"discNumber,omitempty"`
+
+	// EnrichmentStatus Whether the song details were filled from the music info service.
+	EnrichmentStatus *SongEnrichmentStatus `json:"enrichmentStatus,omitempty"`
+
 	// GroupName Name of the group or artist.
 	GroupName string `json:"groupName"`
 
@@ -29,8 +237,11 @@ type Song struct {
 	// Link Link to the song.
 	Link string `json:"link"`
 
-	// ReleaseDate Release date.
-	ReleaseDate int64 `json:"releaseDate"`
+	// ReleaseDate Release date in ISO 8601 format, absent when unknown. A YYYYMMDD string ("20060716") or an RFC 3339 timestamp is also accepted as input, as is a JSON number of Unix seconds, 0 standing for an unknown date. Numbers are never read as YYYYMMDD.
+	ReleaseDate *Date `json:"releaseDate,omitempty"`
+
+	// Score Trigram similarity to the fuzzy filters, only returned when fuzzy=true.
+	Score *float64 `json:"score,omitempty"`
 
 	// SongText Lyrics of the song.
 	SongText string `json:"songText"`
@@ -38,15 +249,79 @@ type Song struct {
 	// SongTitle Title of the song.
 	SongTitle string `json:"songTitle"`
 
+	// TrackNumber Track number on the disc.
+	TrackNumber *int64 `json:"trackNumber,omitempty"`
+
 	// UpdatedAt Record update timestamp.
 	UpdatedAt int64 `json:"updatedAt"`
 }
 
+// SongEnrichmentStatus Whether the song details were filled from the music info service.
+type SongEnrichmentStatus string
+
 // SongCreateRequest defines model for SongCreateRequest.
 type SongCreateRequest struct {
 	Song *Song `json:"song,omitempty"`
 }
 
+// SongListResponse defines model for SongListResponse.
+type SongListResponse struct {
+	Items []*Song `json:"items"`
+
+	// NextCursor Cursor of the next page, absent on the last page.
+	NextCursor *string `json:"nextCursor,omitempty"`
+
+	// Page Current page number, absent for pages requested by a cursor.
+	Page *int64 `json:"page,omitempty"`
+
+	// PageSize Number of items per page.
+	PageSize int64 `json:"pageSize"`
+
+	// PrevCursor Cursor of the previous page, absent on the first page.
+	PrevCursor *string `json:"prevCursor,omitempty"`
+
+	// TotalItems Total number of songs matching the filters.
+	TotalItems int64 `json:"totalItems"`
+
+	// TotalPages Total number of pages.
+	TotalPages int64 `json:"totalPages"`
+}
+
+// SongRevision defines model for SongRevision.
+type SongRevision struct {
+	// Action Change that produced the revision.
+	Action SongRevisionAction `json:"action"`
+
+	// Changes Fields changed since the previous revision.
+	Changes []SongFieldChange `json:"changes"`
+
+	// CreatedAt Time of the change, as a Unix timestamp.
+	CreatedAt int64 `json:"createdAt"`
+
+	// Revision Number of the revision, starting at 1.
+	Revision int64 `json:"revision"`
+	Song     *Song `json:"song,omitempty"`
+}
+
+// SongRevisionAction Change that produced the revision.
+type SongRevisionAction string
+
+// SongSearchResult defines model for SongSearchResult.
+type SongSearchResult struct {
+	// Rank Relevance of the song to the query, higher is better.
+	Rank float64 `json:"rank"`
+
+	// Snippet Fragment of the song text with the matched words wrapped in <mark> tags.
+	Snippet string `json:"snippet"`
+	Song    *Song  `json:"song,omitempty"`
+}
+
+// SongTagsRequest defines model for SongTagsRequest.
+type SongTagsRequest struct {
+	// Tags Tags to assign to the song.
+	Tags []Tag `json:"tags"`
+}
+
 // SongTextResponse defines model for SongTextResponse.
 type SongTextResponse struct {
 	// Page Current page number.
@@ -70,21 +345,341 @@ type SongUpdateRequest struct {
 	Song *Song `json:"song,omitempty"`
 }
 
+// SongFieldChange defines model for SongFieldChange.
+type SongFieldChange struct {
+	// Field Name of the changed song field.
+	Field string `json:"field"`
+
+	// New Value of the field after the change, missing when the field was emptied.
+	New interface{} `json:"new,omitempty"`
+
+	// Old Value of the field before the change, missing when the field was empty.
+	Old interface{} `json:"old,omitempty"`
+}
+
+// SongImportReport defines model for SongImportReport.
+type SongImportReport struct {
+	// Committed Whether the imported rows were saved.
+	Committed bool `json:"committed"`
+
+	// DryRun Whether the import was a dry run.
+	DryRun bool `json:"dryRun"`
+
+	// Failed Number of rows that failed.
+	Failed int64 `json:"failed"`
+
+	// Imported Number of rows imported, or that would be imported by a dry run.
+	Imported int64 `json:"imported"`
+
+	// Mode Whether every row is imported or none (atomic), or the valid rows are imported (bestEffort).
+	Mode SongImportMode `json:"mode"`
+
+	// Rows Outcome of every row, in the order of the body.
+	Rows []SongImportRow `json:"rows"`
+
+	// Total Number of rows read.
+	Total int64 `json:"total"`
+}
+
+// SongImportMode Whether every row is imported or none (atomic), or the valid rows are imported (bestEffort).
+type SongImportMode string
+
+// SongImportRow defines model for SongImportRow.
+type SongImportRow struct {
+	// Error Why the row failed.
+	Error *string `json:"error,omitempty"`
+
+	// Id Identifier of the imported song, not set by dry runs.
+	Id *int64 `json:"id,omitempty"`
+
+	// Line Line of the body the row starts on.
+	Line int64 `json:"line"`
+
+	// Status Outcome of the row; skipped rows are valid rows left out by a failed atomic import.
+	Status SongImportRowStatus `json:"status"`
+}
+
+// SongImportRowStatus Outcome of the row; skipped rows are valid rows left out by a failed atomic import.
+type SongImportRowStatus string
+
+// SongBatchMode Whether the operations are applied in one transaction (atomic) or one by one (independent).
+type SongBatchMode string
+
+// SongBatchOperation defines model for SongBatchOperation.
+type SongBatchOperation struct {
+	// Id Identifier of the song to update or delete.
+	Id *int64 `json:"id,omitempty"`
+
+	// Op Operation to apply.
+	Op SongBatchOperationOp `json:"op"`
+
+	// Song Song to create, or the fields to update.
+	Song *Song `json:"song,omitempty"`
+
+	// Version Version the song must be at for an update or a deletion, as If-Match does.
+	Version *int64 `json:"version,omitempty"`
+}
+
+// SongBatchOperationOp Operation to apply.
+type SongBatchOperationOp string
+
+// SongBatchRequest defines model for SongBatchRequest.
+type SongBatchRequest struct {
+	// Mode Whether the operations are applied in one transaction (atomic) or one by one (independent).
+	Mode *SongBatchMode `json:"mode,omitempty"`
+
+	// Operations Operations to apply, in order.
+	Operations []SongBatchOperation `json:"operations"`
+}
+
+// SongBatchResponse defines model for SongBatchResponse.
+type SongBatchResponse struct {
+	// Committed Whether any operation was saved.
+	Committed bool `json:"committed"`
+
+	// Failed Number of operations that failed.
+	Failed int64 `json:"failed"`
+
+	// Mode Whether the operations are applied in one transaction (atomic) or one by one (independent).
+	Mode SongBatchMode `json:"mode"`
+
+	// Results Outcome of every operation, in the order of the request.
+	Results []SongBatchResult `json:"results"`
+
+	// Succeeded Number of operations applied.
+	Succeeded int64 `json:"succeeded"`
+}
+
+// SongBatchResult defines model for SongBatchResult.
+type SongBatchResult struct {
+	// Error Why the operation failed, only set for failed operations.
+	Error *ErrorResponse `json:"error,omitempty"`
+
+	// Id Identifier of the song, only set for applied operations.
+	Id *int64 `json:"id,omitempty"`
+
+	// Index Position of the operation in the request.
+	Index int64 `json:"index"`
+
+	// Op Operation to apply.
+	Op SongBatchOperationOp `json:"op"`
+
+	// Song Song as saved by a create or an update.
+	Song *Song `json:"song,omitempty"`
+
+	// Status Outcome of the operation, skipped for the operations of a failed atomic batch.
+	Status SongBatchResultStatus `json:"status"`
+}
+
+// SongBatchResultStatus Outcome of the operation, skipped for the operations of a failed atomic batch.
+type SongBatchResultStatus string
+
+// SongLyrics defines model for SongLyrics.
+type SongLyrics struct {
+	// CreatedAt Time the translation was added, as a Unix timestamp.
+	CreatedAt int64 `json:"createdAt"`
+
+	// Language BCP 47 language tag of the translation.
+	Language string `json:"language"`
+
+	// SongId Song identifier.
+	SongId int64 `json:"songId"`
+
+	// SongText Translated song text.
+	SongText string `json:"songText"`
+
+	// UpdatedAt Time the translation was last changed, as a Unix timestamp.
+	UpdatedAt int64 `json:"updatedAt"`
+}
+
+// SongLyricsRequest defines model for SongLyricsRequest.
+type SongLyricsRequest struct {
+	// SongText Translated song text.
+	SongText string `json:"songText"`
+}
+
+// SongWithVerses defines model for SongWithVerses.
+type SongWithVerses struct {
+	// Language BCP 47 language tag of the translation the page comes from, missing for the original lyrics.
+	Language *string `json:"language,omitempty"`
+
+	// Page Current page number
+	Page int64 `json:"page"`
+
+	// PageSize Number of lines or stanzas per page
+	PageSize int64 `json:"pageSize"`
+	Song     *Song `json:"song,omitempty"`
+
+	// Stanzas Stanzas on the current page, set when unit is stanza
+	Stanzas *[]Stanza `json:"stanzas,omitempty"`
+
+	// TotalStanzas Total number of stanzas in the song
+	TotalStanzas int64 `json:"totalStanzas"`
+
+	// TotalVerses Total number of lines in the song
+	TotalVerses int64 `json:"totalVerses"`
+
+	// Unit Whether the page is made of lines or of stanzas.
+	Unit SongWithVersesUnit `json:"unit"`
+
+	// Verses Lines on the current page, set when unit is line
+	Verses *[]string `json:"verses,omitempty"`
+}
+
+// SongWithVersesUnit Whether the page is made of lines or of stanzas.
+type SongWithVersesUnit string
+
+// Stanza defines model for Stanza.
+type Stanza struct {
+	// Label Section marker as written in the song text.
+	Label *string  `json:"label,omitempty"`
+	Lines []string `json:"lines"`
+
+	// Number Position of the stanza in the song, starting at 1.
+	Number int64 `json:"number"`
+
+	// Section Kind of the section the stanza belongs to, taken from its marker.
+	Section *string `json:"section,omitempty"`
+}
+
 // SuccessResponse Типовой запрос для ответа на Post запросы, которые не должны возвращать никаких данных
 type SuccessResponse struct {
 	Success *bool `json:"success,omitempty"`
 }
 
+// SyncedLine defines model for SyncedLine.
+type SyncedLine struct {
+	// Number Position of the line in the synced lyrics, starting at 1.
+	Number int64 `json:"number"`
+
+	// Text Text of the line, empty for a pause.
+	Text string `json:"text"`
+
+	// Time Playback position the line is shown from, in seconds.
+	Time float64 `json:"time"`
+
+	// Timestamp Playback position the line is shown from, as written in LRC.
+	Timestamp string `json:"timestamp"`
+}
+
+// SyncedLyrics defines model for SyncedLyrics.
+type SyncedLyrics struct {
+	// Lines Lines ordered by the time they are shown.
+	Lines []SyncedLine `json:"lines"`
+
+	// SongId Song identifier.
+	SongId int64 `json:"songId"`
+}
+
+// SyncedLyricsPosition defines model for SyncedLyricsPosition.
+type SyncedLyricsPosition struct {
+	Line *SyncedLine `json:"line,omitempty"`
+	Next *SyncedLine `json:"next,omitempty"`
+
+	// T Playback position, in seconds.
+	T float64 `json:"t"`
+}
+
+// Tag defines model for Tag.
+type Tag struct {
+	// Id Tag identifier.
+	Id *int64 `json:"id,omitempty"`
+
+	// Kind Kind of the tag.
+	Kind *TagKind `json:"kind,omitempty"`
+
+	// Name Name of the tag or genre.
+	Name string `json:"name"`
+}
+
+// TagKind Kind of the tag.
+type TagKind string
+
+// TrackPosition defines model for TrackPosition.
+type TrackPosition struct {
+	// DiscNumber Disc number within the album.
+	DiscNumber *int64 `json:"discNumber,omitempty"`
+
+	// SongId Song identifier.
+	SongId int64 `json:"songId"`
+
+	// TrackNumber Track number on the disc.
+	TrackNumber int64 `json:"trackNumber"`
+}
+
+// GetArtistsFilterParams defines parameters for GetArtistsFilter.
+type GetArtistsFilterParams struct {
+	// Name Filter by artist name
+	Name *string `form:"name,omitempty" json:"name,omitempty"`
+
+	// Page Page number for pagination
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// PageSize Number of items per page
+	PageSize *int `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+}
+
 // GetSongsFilterParams defines parameters for GetSongsFilter.
 type GetSongsFilterParams struct {
 	// GroupName Filter by group name
 	GroupName *string `form:"groupName,omitempty" json:"groupName,omitempty"`
 
+	// ArtistId Filter by artist identifier
+	ArtistId *int64 `form:"artistId,omitempty" json:"artistId,omitempty"`
+
+	// AlbumId Filter by album identifier
+	AlbumId *int64 `form:"albumId,omitempty" json:"albumId,omitempty"`
+
 	// SongTitle Filter by song title
 	SongTitle *string `form:"songTitle,omitempty" json:"songTitle,omitempty"`
 
-	// ReleaseDate Filter by release date
-	ReleaseDate *int64 `form:"releaseDate,omitempty" json:"releaseDate,omitempty"`
+	// ReleaseDate Filter by release date, an ISO 8601 date or a Unix timestamp.
+	ReleaseDate *string `form:"releaseDate,omitempty" json:"releaseDate,omitempty"`
+
+	// ReleasedFrom Earliest release date, inclusive.
+	ReleasedFrom *string `form:"releasedFrom,omitempty" json:"releasedFrom,omitempty"`
+
+	// ReleasedTo Latest release date, inclusive.
+	ReleasedTo *string `form:"releasedTo,omitempty" json:"releasedTo,omitempty"`
+
+	// Tags Comma-separated list of tags
+	Tags *string `form:"tags,omitempty" json:"tags,omitempty"`
+
+	// TagsMatch Whether a song must have any or all of the tags
+	TagsMatch *GetSongsFilterParamsTagsMatch `form:"tagsMatch,omitempty" json:"tagsMatch,omitempty"`
+
+	// Genre Filter by genre
+	Genre *string `form:"genre,omitempty" json:"genre,omitempty"`
+
+	// Fuzzy Match groupName and songTitle by trigram similarity and order by the score.
+	Fuzzy *bool `form:"fuzzy,omitempty" json:"fuzzy,omitempty"`
+
+	// IncludeDeleted List the songs in the trash along with the others, an admin option.
+	IncludeDeleted *bool `form:"includeDeleted,omitempty" json:"includeDeleted,omitempty"`
+
+	// Page Page number for pagination
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// PageSize Number of items per page
+	PageSize *int `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+
+	// After Return the page following this cursor.
+	After *string `form:"after,omitempty" json:"after,omitempty"`
+
+	// Before Return the page preceding this cursor.
+	Before *string `form:"before,omitempty" json:"before,omitempty"`
+}
+
+// GetSongsFilterParamsTagsMatch defines parameters for GetSongsFilter.
+type GetSongsFilterParamsTagsMatch string
+
+// GetSongsSearchParams defines parameters for GetSongsSearch.
+type GetSongsSearchParams struct {
+	// Q Search query in web search syntax.
+	Q string `form:"q" json:"q"`
+
+	// Lang Text search language. Detected from the query when omitted.
+	Lang *GetSongsSearchParamsLang `form:"lang,omitempty" json:"lang,omitempty"`
 
 	// Page Page number for pagination
 	Page *int `form:"page,omitempty" json:"page,omitempty"`
@@ -93,17 +688,131 @@ type GetSongsFilterParams struct {
 	PageSize *int `form:"pageSize,omitempty" json:"pageSize,omitempty"`
 }
 
+// GetSongsSearchParamsLang defines parameters for GetSongsSearch.
+type GetSongsSearchParamsLang string
+
+// GetApiV1SongsIdParams defines parameters for GetApiV1SongsId.
+type GetApiV1SongsIdParams struct {
+	// Fields Comma-separated list of the song fields to return.
+	Fields *string `form:"fields,omitempty" json:"fields,omitempty"`
+}
+
 // GetSongsIdTextParams defines parameters for GetSongsIdText.
 type GetSongsIdTextParams struct {
 	// Page Page number for verse pagination.
 	Page *int `form:"page,omitempty" json:"page,omitempty"`
 
+	// Lang BCP 47 language tag of the preferred translation, takes precedence over Accept-Language.
+	Lang *string `form:"lang,omitempty" json:"lang,omitempty"`
+
 	// PageSize Number of verses per page.
 	PageSize *int `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+
+	// Unit Whether the lyrics are paginated by line or by stanza.
+	Unit *SongWithVersesUnit `form:"unit,omitempty" json:"unit,omitempty"`
 }
 
-// PostSongsFilterJSONRequestBody defines body for PostSongsFilter for application/json ContentType.
-type PostSongsFilterJSONRequestBody = SongCreateRequest
+// GetSongsExportParams defines parameters for GetSongsExport.
+type GetSongsExportParams struct {
+	// Format Format of the export.
+	Format *GetSongsExportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// GroupName Filter by group name
+	GroupName *string `form:"groupName,omitempty" json:"groupName,omitempty"`
+
+	// ArtistId Filter by artist identifier
+	ArtistId *int64 `form:"artistId,omitempty" json:"artistId,omitempty"`
+
+	// AlbumId Filter by album identifier
+	AlbumId *int64 `form:"albumId,omitempty" json:"albumId,omitempty"`
+
+	// SongTitle Filter by song title
+	SongTitle *string `form:"songTitle,omitempty" json:"songTitle,omitempty"`
+
+	// ReleaseDate Filter by release date, an ISO 8601 date or a Unix timestamp.
+	ReleaseDate *string `form:"releaseDate,omitempty" json:"releaseDate,omitempty"`
+
+	// ReleasedFrom Earliest release date, inclusive.
+	ReleasedFrom *string `form:"releasedFrom,omitempty" json:"releasedFrom,omitempty"`
+
+	// ReleasedTo Latest release date, inclusive.
+	ReleasedTo *string `form:"releasedTo,omitempty" json:"releasedTo,omitempty"`
+
+	// Tags Comma-separated list of tags
+	Tags *string `form:"tags,omitempty" json:"tags,omitempty"`
+
+	// TagsMatch Whether a song must have any or all of the tags
+	TagsMatch *GetSongsFilterParamsTagsMatch `form:"tagsMatch,omitempty" json:"tagsMatch,omitempty"`
+
+	// Genre Filter by genre
+	Genre *string `form:"genre,omitempty" json:"genre,omitempty"`
+
+	// Fuzzy Match groupName and songTitle by trigram similarity and order by the score.
+	Fuzzy *bool `form:"fuzzy,omitempty" json:"fuzzy,omitempty"`
+
+	// IncludeDeleted Export the songs in the trash along with the others, an admin option.
+	IncludeDeleted *bool `form:"includeDeleted,omitempty" json:"includeDeleted,omitempty"`
+
+	// Sort Comma-separated sort fields, descending when prefixed with a minus.
+	Sort *string `form:"sort,omitempty" json:"sort,omitempty"`
+}
+
+// GetSongsExportParamsFormat defines parameters for GetSongsExport.
+type GetSongsExportParamsFormat string
+
+// PostSongsImportParams defines parameters for PostSongsImport.
+type PostSongsImportParams struct {
+	// Format Format of the body, taken from Content-Type when omitted.
+	Format *PostSongsImportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Mode Whether every row is imported or none (atomic), or the valid rows are imported (bestEffort).
+	Mode *SongImportMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// DryRun Check the rows without saving them.
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+}
+
+// PostSongsImportParamsFormat defines parameters for PostSongsImport.
+type PostSongsImportParamsFormat string
+
+// GetSongsIdLyricsAtParams defines parameters for GetSongsIdLyricsAt.
+type GetSongsIdLyricsAtParams struct {
+	// T Playback position, in seconds.
+	T float64 `form:"t" json:"t"`
+}
+
+// PutSongsIdLyricsTranslationsLangJSONRequestBody defines body for PutSongsIdLyricsTranslationsLang for application/json ContentType.
+type PutSongsIdLyricsTranslationsLangJSONRequestBody = SongLyricsRequest
+
+// PostApiV1SongsJSONRequestBody defines body for PostApiV1Songs for application/json ContentType.
+type PostApiV1SongsJSONRequestBody = SongCreateRequest
+
+// PostSongsCreateJSONRequestBody defines body for PostSongsCreate for application/json ContentType.
+type PostSongsCreateJSONRequestBody = SongCreateRequest
+
+// PostSongsBatchJSONRequestBody defines body for PostSongsBatch for application/json ContentType.
+type PostSongsBatchJSONRequestBody = SongBatchRequest
+
+// PostAlbumsCreateJSONRequestBody defines body for PostAlbumsCreate for application/json ContentType.
+type PostAlbumsCreateJSONRequestBody = AlbumCreateRequest
+
+// PutAlbumsIdTracksOrderJSONRequestBody defines body for PutAlbumsIdTracksOrder for application/json ContentType.
+type PutAlbumsIdTracksOrderJSONRequestBody = AlbumTracksReorderRequest
+
+// PostArtistsCreateJSONRequestBody defines body for PostArtistsCreate for application/json ContentType.
+type PostArtistsCreateJSONRequestBody = ArtistCreateRequest
+
+// PatchArtistsIdUpdateJSONRequestBody defines body for PatchArtistsIdUpdate for application/json ContentType.
+type PatchArtistsIdUpdateJSONRequestBody = ArtistUpdateRequest
+
+// PostSongsIdTagsJSONRequestBody defines body for PostSongsIdTags for application/json ContentType.
+type PostSongsIdTagsJSONRequestBody = SongTagsRequest
+
+// PutSongsIdTagsJSONRequestBody defines body for PutSongsIdTags for application/json ContentType.
+type PutSongsIdTagsJSONRequestBody = SongTagsRequest
+
+// PatchApiV1SongsIdJSONRequestBody defines body for PatchApiV1SongsId for application/json ContentType.
+type PatchApiV1SongsIdJSONRequestBody = SongUpdateRequest
 
-// PatchSongsIdJSONRequestBody defines body for PatchSongsId for application/json ContentType.
-type PatchSongsIdJSONRequestBody = SongUpdateRequest
+// PatchSongsIdUpdateJSONRequestBody defines body for PatchSongsIdUpdate for application/json ContentType.
+type PatchSongsIdUpdateJSONRequestBody = SongUpdateRequest
@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
@@ -9,11 +10,72 @@ import (
 	"github.com/go-ozzo/ozzo-validation/is"
 )
 
+// errBlank matches the message of validation.Required for the objects it
+// cannot check.
+var errBlank = fmt.Errorf("cannot be blank")
+
+// FieldError is a validation error of one field of the request body. Pointer
+// is the JSON pointer to the field, e.g. "/song/link".
+type FieldError struct {
+	Pointer string
+	Err     error
+}
+
+func (e *FieldError) Error() string {
+	return strings.TrimPrefix(e.Pointer, "/") + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+func fieldError(pointer string, err error) error {
+	return &FieldError{Pointer: pointer, Err: err}
+}
+
+// prefixFieldErrors moves the field errors of a nested object under the
+// pointer of the object, errors without a field point at the object itself.
+func prefixFieldErrors(prefix string, err error) error {
+	switch e := err.(type) {
+	case *FieldError:
+		return &FieldError{Pointer: prefix + e.Pointer, Err: e.Err}
+	case *errors.CompositeError:
+		res := make([]error, 0, len(e.Errors))
+		for _, err := range e.Errors {
+			res = append(res, prefixFieldErrors(prefix, err))
+		}
+		return errors.CompositeValidationError(res...)
+	}
+
+	return &FieldError{Pointer: prefix, Err: err}
+}
+
+// FieldErrors returns the field errors found anywhere in the error tree, in
+// the order they were reported.
+func FieldErrors(err error) []*FieldError {
+	switch e := err.(type) {
+	case *FieldError:
+		return []*FieldError{e}
+	case interface{ Unwrap() []error }:
+		var res []*FieldError
+		for _, err := range e.Unwrap() {
+			res = append(res, FieldErrors(err)...)
+		}
+		return res
+	case interface{ Unwrap() error }:
+		return FieldErrors(e.Unwrap())
+	}
+
+	return nil
+}
+
 func (s *SongCreateRequest) Validate(formats strfmt.Registry) error {
 	var res []error
 
-	if err := s.Song.Validate(formats); err != nil {
-		res = append(res, err)
+	if s.Song == nil {
+		res = append(res, fieldError("/song", errBlank))
+	} else if err := s.Song.Validate(formats); err != nil {
+		res = append(res, prefixFieldErrors("/song", err))
 	}
 
 	if len(res) > 0 {
@@ -25,36 +87,36 @@ func (s *SongCreateRequest) Validate(formats strfmt.Registry) error {
 func (s *SongUpdateRequest) Validate(formats strfmt.Registry) error {
 	var res []error
 
-	if s.Song != nil {
-		if err := validation.Validate(*s.Song, validation.NilOrNotEmpty); err != nil {
-			res = append(res, fmt.Errorf("song: %w", err))
-		}
+	if s.Song == nil {
+		return errors.CompositeValidationError(fieldError("/song", errBlank))
+	}
+
+	if err := validation.Validate(*s.Song, validation.NilOrNotEmpty); err != nil {
+		res = append(res, fieldError("/song", err))
 	}
 
 	if s.Song.GroupName != "" {
-		if err := validation.Validate(s.Song.GroupName, validation.NilOrNotEmpty); err != nil {
-			res = append(res, fmt.Errorf("group: %w", err))
+		if err := validation.Validate(strings.TrimSpace(s.Song.GroupName), validation.Required); err != nil {
+			res = append(res, fieldError("/song/groupName", err))
 		}
 	}
 
 	if s.Song.Link != "" {
 		if err := validation.Validate(s.Song.Link, validation.NilOrNotEmpty, is.URL); err != nil {
-			res = append(res, fmt.Errorf("link: %w", err))
-		}
-	}
-
-	if s.Song.ReleaseDate != 0 {
-		if err := validation.Validate(s.Song.ReleaseDate, validation.Min(0)); err != nil {
-			res = append(res, fmt.Errorf("releaseDate: %w", err))
+			res = append(res, fieldError("/song/link", err))
 		}
 	}
 
 	if s.Song.SongText != "" {
 		if err := validation.Validate(s.Song.SongText, validation.NilOrNotEmpty); err != nil {
-			res = append(res, fmt.Errorf("text: %w", err))
+			res = append(res, fieldError("/song/songText", err))
 		}
 	}
 
+	for _, err := range validateSongTrack(s.Song) {
+		res = append(res, prefixFieldErrors("/song", err))
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -64,12 +126,257 @@ func (s *SongUpdateRequest) Validate(formats strfmt.Registry) error {
 func (s *Song) Validate(formats strfmt.Registry) error {
 	var res []error
 
-	if err := validation.Validate(s.GroupName, validation.Required); err != nil {
-		res = append(res, err)
+	if err := validation.Validate(strings.TrimSpace(s.GroupName), validation.Required); err != nil {
+		res = append(res, fieldError("/groupName", err))
 	}
 
 	if err := validation.Validate(s.SongTitle, validation.Required); err != nil {
-		res = append(res, err)
+		res = append(res, fieldError("/songTitle", err))
+	}
+
+	res = append(res, validateSongTrack(s)...)
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (a *ArtistCreateRequest) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if a.Artist == nil {
+		res = append(res, fieldError("/artist", errBlank))
+	} else if err := a.Artist.Validate(formats); err != nil {
+		res = append(res, prefixFieldErrors("/artist", err))
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (a *ArtistUpdateRequest) Validate(formats strfmt.Registry) error {
+	return (*ArtistCreateRequest)(a).Validate(formats)
+}
+
+func (a *Artist) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := validation.Validate(strings.TrimSpace(a.Name), validation.Required, validation.Length(1, 255)); err != nil {
+		res = append(res, fieldError("/name", err))
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (a *AlbumCreateRequest) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if a.Album == nil {
+		res = append(res, fieldError("/album", errBlank))
+	} else if err := a.Album.Validate(formats); err != nil {
+		res = append(res, prefixFieldErrors("/album", err))
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (a *Album) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := validation.Validate(strings.TrimSpace(a.GroupName), validation.Required); err != nil {
+		res = append(res, fieldError("/groupName", err))
+	}
+
+	if err := validation.Validate(a.Title, validation.Required, validation.Length(1, 255)); err != nil {
+		res = append(res, fieldError("/title", err))
+	}
+
+	if a.CoverLink != "" {
+		if err := validation.Validate(a.CoverLink, is.URL); err != nil {
+			res = append(res, fieldError("/coverLink", err))
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (a *AlbumTracksReorderRequest) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if len(a.Tracks) == 0 {
+		res = append(res, fieldError("/tracks", errBlank))
+	}
+
+	songs := make(map[int64]struct{}, len(a.Tracks))
+	positions := make(map[[2]int64]struct{}, len(a.Tracks))
+	for i, track := range a.Tracks {
+		if err := validation.Validate(track.SongId, validation.Required, validation.Min(1)); err != nil {
+			res = append(res, fieldError(fmt.Sprintf("/tracks/%d/songId", i), err))
+		}
+		if err := validation.Validate(track.TrackNumber, validation.Required, validation.Min(1)); err != nil {
+			res = append(res, fieldError(fmt.Sprintf("/tracks/%d/trackNumber", i), err))
+		}
+
+		discNumber := int64(1)
+		if track.DiscNumber != nil {
+			discNumber = *track.DiscNumber
+			if err := validation.Validate(discNumber, validation.Min(1)); err != nil {
+				res = append(res, fieldError(fmt.Sprintf("/tracks/%d/discNumber", i), err))
+			}
+		}
+
+		if _, ok := songs[track.SongId]; ok {
+			res = append(res, fieldError(fmt.Sprintf("/tracks/%d/songId", i), fmt.Errorf("duplicate song %d", track.SongId)))
+		}
+		songs[track.SongId] = struct{}{}
+
+		position := [2]int64{discNumber, track.TrackNumber}
+		if _, ok := positions[position]; ok {
+			res = append(res, fieldError(fmt.Sprintf("/tracks/%d", i), fmt.Errorf("duplicate position %d-%d", discNumber, track.TrackNumber)))
+		}
+		positions[position] = struct{}{}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func validateSongTrack(s *Song) []error {
+	var res []error
+
+	if s.AlbumId == nil {
+		if s.DiscNumber != nil || s.TrackNumber != nil {
+			res = append(res, fieldError("/albumId", fmt.Errorf("is required when a track position is set")))
+		}
+		return res
+	}
+
+	if err := validation.Validate(*s.AlbumId, validation.Min(1)); err != nil {
+		res = append(res, fieldError("/albumId", err))
+	}
+	if s.DiscNumber != nil {
+		if err := validation.Validate(*s.DiscNumber, validation.Min(1)); err != nil {
+			res = append(res, fieldError("/discNumber", err))
+		}
+	}
+	if s.TrackNumber != nil {
+		if err := validation.Validate(*s.TrackNumber, validation.Min(1)); err != nil {
+			res = append(res, fieldError("/trackNumber", err))
+		}
+	}
+
+	return res
+}
+
+func (t *SongTagsRequest) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	for i, tag := range t.Tags {
+		if err := validation.Validate(strings.TrimSpace(tag.Name), validation.Required, validation.Length(1, 255)); err != nil {
+			res = append(res, fieldError(fmt.Sprintf("/tags/%d/name", i), err))
+		}
+		if tag.Kind != nil {
+			if err := validation.Validate(string(*tag.Kind), validation.In(string(TagKindGenre), string(TagKindTag))); err != nil {
+				res = append(res, fieldError(fmt.Sprintf("/tags/%d/kind", i), err))
+			}
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (l *SongLyricsRequest) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := validation.Validate(strings.TrimSpace(l.SongText), validation.Required); err != nil {
+		res = append(res, fieldError("/songText", err))
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// maxSongBatchOperations bounds the number of operations of a batch.
+const maxSongBatchOperations = 1000
+
+// Validate checks the batch as a whole, the operations are checked one by one
+// by ValidateOperation so that an invalid one only fails itself.
+func (b *SongBatchRequest) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if b.Mode != nil {
+		if err := validation.Validate(string(*b.Mode), validation.In(string(SongBatchModeAtomic), string(SongBatchModeIndependent))); err != nil {
+			res = append(res, fieldError("/mode", err))
+		}
+	}
+
+	if len(b.Operations) == 0 {
+		res = append(res, fieldError("/operations", errBlank))
+	} else if len(b.Operations) > maxSongBatchOperations {
+		res = append(res, fieldError("/operations", fmt.Errorf("must have at most %d operations", maxSongBatchOperations)))
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// ValidateOperation checks the operation at index i, the errors point into the
+// batch request.
+func (b *SongBatchRequest) ValidateOperation(i int, formats strfmt.Registry) error {
+	if err := b.Operations[i].Validate(formats); err != nil {
+		return prefixFieldErrors(fmt.Sprintf("/operations/%d", i), err)
+	}
+	return nil
+}
+
+func (o *SongBatchOperation) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	switch o.Op {
+	case SongBatchOperationOpCreate:
+		if err := (&SongCreateRequest{Song: o.Song}).Validate(formats); err != nil {
+			res = append(res, err)
+		}
+	case SongBatchOperationOpUpdate:
+		if err := (&SongUpdateRequest{Song: o.Song}).Validate(formats); err != nil {
+			res = append(res, err)
+		}
+	case SongBatchOperationOpDelete:
+		if o.Song != nil {
+			res = append(res, fieldError("/song", fmt.Errorf("must not be set for a deletion")))
+		}
+	default:
+		res = append(res, fieldError("/op", fmt.Errorf("must be one of %s, %s, %s",
+			SongBatchOperationOpCreate, SongBatchOperationOpUpdate, SongBatchOperationOpDelete)))
+	}
+
+	if o.Op == SongBatchOperationOpUpdate || o.Op == SongBatchOperationOpDelete {
+		if o.Id == nil {
+			res = append(res, fieldError("/id", errBlank))
+		} else if err := validation.Validate(*o.Id, validation.Min(1)); err != nil {
+			res = append(res, fieldError("/id", err))
+		}
 	}
 
 	if len(res) > 0 {